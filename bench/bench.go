@@ -0,0 +1,178 @@
+// Package bench is a small, testing.B-style harness for running the
+// benchmarks in this repo outside of `go test`. It exists because these
+// benchmarks compare against non-Go runtimes (Python, Node, Rust) and need
+// to run as plain binaries, but we still want testing.B ergonomics:
+// auto-sizing iteration counts, ResetTimer/StopTimer/StartTimer, and
+// allocation reporting.
+package bench
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// B is passed to a benchmark function. It tracks elapsed wall-clock time
+// and, when ReportAllocs is called, allocation counts, the same way
+// testing.B does.
+type B struct {
+	N int
+
+	start    time.Time
+	duration time.Duration
+	timerOn  bool
+
+	reportAllocs bool
+	startAllocs  uint64
+	startBytes   uint64
+	netAllocs    uint64
+	netBytes     uint64
+}
+
+// StartTimer starts timing a benchmark. It is called automatically before
+// a benchmark function runs, so it's only needed after a StopTimer call
+// to resume timing setup-free work.
+func (b *B) StartTimer() {
+	if b.timerOn {
+		return
+	}
+	if b.reportAllocs {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		b.startAllocs = m.Mallocs
+		b.startBytes = m.TotalAlloc
+	}
+	b.start = time.Now()
+	b.timerOn = true
+}
+
+// StopTimer stops timing a benchmark, for excluding setup that happens
+// inside the benchmark function from the reported time.
+func (b *B) StopTimer() {
+	if !b.timerOn {
+		return
+	}
+	b.duration += time.Since(b.start)
+	if b.reportAllocs {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		b.netAllocs += m.Mallocs - b.startAllocs
+		b.netBytes += m.TotalAlloc - b.startBytes
+	}
+	b.timerOn = false
+}
+
+// ResetTimer zeroes the elapsed time and allocation counters accumulated
+// so far, without affecting whether the timer is currently running. Use
+// it after expensive per-scenario setup.
+func (b *B) ResetTimer() {
+	if b.timerOn {
+		if b.reportAllocs {
+			var m runtime.MemStats
+			runtime.ReadMemStats(&m)
+			b.startAllocs = m.Mallocs
+			b.startBytes = m.TotalAlloc
+		}
+		b.start = time.Now()
+	}
+	b.duration = 0
+	b.netAllocs = 0
+	b.netBytes = 0
+}
+
+// ReportAllocs enables allocation counting for this benchmark's remaining
+// run. Costs an extra runtime.ReadMemStats per timer start/stop, so it's
+// opt-in rather than always-on.
+func (b *B) ReportAllocs() {
+	if b.reportAllocs {
+		return
+	}
+	b.reportAllocs = true
+	if b.timerOn {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		b.startAllocs = m.Mallocs
+		b.startBytes = m.TotalAlloc
+	}
+}
+
+// elapsed returns the accumulated timed duration, including any time
+// currently in flight between StartTimer and now.
+func (b *B) elapsed() time.Duration {
+	d := b.duration
+	if b.timerOn {
+		d += time.Since(b.start)
+	}
+	return d
+}
+
+// allocs returns accumulated (mallocs, bytes) since the last ResetTimer,
+// including any in-flight interval.
+func (b *B) allocs() (mallocs, bytes uint64) {
+	mallocs, bytes = b.netAllocs, b.netBytes
+	if b.timerOn && b.reportAllocs {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		mallocs += m.Mallocs - b.startAllocs
+		bytes += m.TotalAlloc - b.startBytes
+	}
+	return mallocs, bytes
+}
+
+// runOnce runs fn with an auto-sized N until it satisfies target (either
+// a minimum duration or a minimum iteration count, per BenchTime),
+// doubling N after each attempt that falls short. It returns the B used for
+// the final, reported iteration, or an error if fn panicked (recovered
+// so one broken scenario can't take down the rest of a Runner's report).
+func runOnce(fn func(*B), target BenchTime) (b *B, err error) {
+	b = &B{N: 1}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	for {
+		b.N = nextN(b.N, target)
+		b.duration = 0
+		b.netAllocs = 0
+		b.netBytes = 0
+		b.timerOn = false
+		b.StartTimer()
+		fn(b)
+		b.StopTimer()
+
+		if target.satisfied(b) {
+			return b, nil
+		}
+	}
+}
+
+// nextN picks the next iteration count to try: target.Iterations in Nx
+// mode, otherwise twice prev (2, 4, 8, ... from runOnce's initial N of
+// 1). It does not extrapolate from the previous run's timing.
+func nextN(prev int, target BenchTime) int {
+	if target.Iterations > 0 {
+		return target.Iterations
+	}
+	n := prev * 2
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// satisfied reports whether b's run meets target.
+func (t BenchTime) satisfied(b *B) bool {
+	if t.Iterations > 0 {
+		return b.N >= t.Iterations
+	}
+	return b.elapsed() >= t.Duration
+}
+
+// BenchTime is the parsed form of a "-benchtime=Ns" or "-benchtime=Nx"
+// flag: run until either a wall-clock duration or an iteration count is
+// reached.
+type BenchTime struct {
+	Duration   time.Duration // used when Iterations == 0
+	Iterations int
+}
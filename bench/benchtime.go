@@ -0,0 +1,30 @@
+package bench
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseBenchTime parses the value of a "-benchtime" flag: either a
+// duration like "1s" / "500ms" (run until that much time has elapsed) or
+// a count like "100x" (run exactly that many iterations), matching the
+// syntax `go test -benchtime` accepts.
+func ParseBenchTime(s string) (BenchTime, error) {
+	if s == "" {
+		return BenchTime{Duration: time.Second}, nil
+	}
+	if n := strings.TrimSuffix(s, "x"); n != s {
+		iters, err := strconv.Atoi(n)
+		if err != nil || iters <= 0 {
+			return BenchTime{}, fmt.Errorf("bench: invalid -benchtime %q: want Nx with N > 0", s)
+		}
+		return BenchTime{Iterations: iters}, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return BenchTime{}, fmt.Errorf("bench: invalid -benchtime %q: want a duration like 1s or a count like 100x", s)
+	}
+	return BenchTime{Duration: d}, nil
+}
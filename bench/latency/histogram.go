@@ -0,0 +1,217 @@
+// Package latency records latency samples into a logarithmic,
+// HDR-histogram-style bucket structure (3 significant decimal digits,
+// covering 1us to 60s) instead of keeping every sample. Recording is
+// O(1) and the memory footprint is bounded regardless of sample count,
+// which a sort.Float64s-based approach can't offer once you're past a
+// few hundred thousand samples.
+//
+// A Recorder is meant to be owned by a single goroutine (no internal
+// locking); spawn one per worker and Merge them when a run finishes.
+package latency
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+const (
+	lowestTrackableValue  = int64(time.Microsecond)
+	highestTrackableValue = int64(60 * time.Second)
+	significantFigures    = 3
+)
+
+// Recorder buckets latency samples logarithmically. The zero value is
+// not usable; construct one with NewRecorder.
+type Recorder struct {
+	counts []int64
+
+	unitMagnitude               int
+	subBucketHalfCountMagnitude int
+	subBucketCount              int
+	subBucketHalfCount          int
+	subBucketMask               int64
+	bucketCount                 int
+
+	totalCount int64
+}
+
+// NewRecorder returns a Recorder ready to record durations between 1us
+// and 60s (samples outside that range are clamped to the nearest edge)
+// with 3 significant decimal digits of resolution.
+func NewRecorder() *Recorder {
+	r := &Recorder{}
+	r.unitMagnitude = int(math.Log2(float64(lowestTrackableValue)))
+
+	largestValueWithSingleUnitResolution := 2 * math.Pow10(significantFigures)
+	subBucketCountMagnitude := int(math.Ceil(math.Log2(largestValueWithSingleUnitResolution)))
+	if subBucketCountMagnitude < 1 {
+		subBucketCountMagnitude = 1
+	}
+	r.subBucketHalfCountMagnitude = subBucketCountMagnitude - 1
+	r.subBucketCount = 1 << uint(subBucketCountMagnitude)
+	r.subBucketHalfCount = r.subBucketCount / 2
+	r.subBucketMask = int64(r.subBucketCount-1) << uint(r.unitMagnitude)
+
+	r.bucketCount = bucketsNeeded(highestTrackableValue, r.subBucketCount, r.unitMagnitude)
+	countsLen := (r.bucketCount + 1) * r.subBucketHalfCount
+	r.counts = make([]int64, countsLen)
+	return r
+}
+
+// bucketsNeeded returns how many doubling "buckets" are needed so that
+// subBucketCount<<unitMagnitude, doubled bucketCount times, covers
+// highestTrackableValue.
+func bucketsNeeded(highestTrackableValue int64, subBucketCount, unitMagnitude int) int {
+	smallestUntrackableValue := int64(subBucketCount) << uint(unitMagnitude)
+	bucketsNeeded := 1
+	for smallestUntrackableValue <= highestTrackableValue {
+		if smallestUntrackableValue > math.MaxInt64/2 {
+			return bucketsNeeded + 1
+		}
+		smallestUntrackableValue <<= 1
+		bucketsNeeded++
+	}
+	return bucketsNeeded
+}
+
+// RecordValue records a single latency sample, clamped to
+// [1us, 60s] before bucketing.
+func (r *Recorder) RecordValue(d time.Duration) {
+	v := int64(d)
+	if v < lowestTrackableValue {
+		v = lowestTrackableValue
+	}
+	if v > highestTrackableValue {
+		v = highestTrackableValue
+	}
+	r.counts[r.countsIndexFor(v)]++
+	r.totalCount++
+}
+
+func (r *Recorder) bucketIndexFor(v int64) int {
+	pow2Ceiling := 64 - bits.LeadingZeros64(uint64(v|r.subBucketMask))
+	return pow2Ceiling - r.unitMagnitude - (r.subBucketHalfCountMagnitude + 1)
+}
+
+func (r *Recorder) subBucketIndexFor(v int64, bucketIndex int) int {
+	return int(v >> uint(bucketIndex+r.unitMagnitude))
+}
+
+func (r *Recorder) countsIndexFor(v int64) int {
+	bucketIndex := r.bucketIndexFor(v)
+	subBucketIndex := r.subBucketIndexFor(v, bucketIndex)
+	bucketBaseIndex := (bucketIndex + 1) << uint(r.subBucketHalfCountMagnitude)
+	offsetInBucket := subBucketIndex - r.subBucketHalfCount
+	return bucketBaseIndex + offsetInBucket
+}
+
+// valueFromIndex returns the representative (lower-bound) value of the
+// bucket at the given counts index; the inverse of countsIndexFor.
+func (r *Recorder) valueFromIndex(index int) int64 {
+	bucketIndex := (index >> uint(r.subBucketHalfCountMagnitude)) - 1
+	subBucketIndex := (index & (r.subBucketHalfCount - 1)) + r.subBucketHalfCount
+	if bucketIndex < 0 {
+		subBucketIndex -= r.subBucketHalfCount
+		bucketIndex = 0
+	}
+	return int64(subBucketIndex) << uint(bucketIndex+r.unitMagnitude)
+}
+
+// TotalCount returns the number of samples recorded.
+func (r *Recorder) TotalCount() int64 {
+	return r.totalCount
+}
+
+// Max returns the largest recorded sample, rounded down to its bucket's
+// representative value.
+func (r *Recorder) Max() time.Duration {
+	for i := len(r.counts) - 1; i >= 0; i-- {
+		if r.counts[i] > 0 {
+			return time.Duration(r.valueFromIndex(i))
+		}
+	}
+	return 0
+}
+
+// Quantile returns the value at or below which the given fraction
+// (0..1) of samples fall, e.g. Quantile(0.99) is p99.
+func (r *Recorder) Quantile(q float64) time.Duration {
+	if r.totalCount == 0 {
+		return 0
+	}
+	target := int64(math.Ceil(q * float64(r.totalCount)))
+	var cumulative int64
+	for i, c := range r.counts {
+		cumulative += c
+		if cumulative >= target {
+			return time.Duration(r.valueFromIndex(i))
+		}
+	}
+	return r.Max()
+}
+
+// CDFPoint is one step of a cumulative distribution: Fraction of samples
+// are <= Value.
+type CDFPoint struct {
+	Value    time.Duration
+	Fraction float64
+}
+
+// CDF returns the cumulative distribution over every non-empty bucket,
+// suitable for plotting.
+func (r *Recorder) CDF() []CDFPoint {
+	if r.totalCount == 0 {
+		return nil
+	}
+	points := make([]CDFPoint, 0, len(r.counts))
+	var cumulative int64
+	for i, c := range r.counts {
+		if c == 0 {
+			continue
+		}
+		cumulative += c
+		points = append(points, CDFPoint{
+			Value:    time.Duration(r.valueFromIndex(i)),
+			Fraction: float64(cumulative) / float64(r.totalCount),
+		})
+	}
+	return points
+}
+
+// Merge returns a new Recorder whose counts are the sum of rs. All rs
+// must have been created with the same parameters (e.g. via
+// NewRecorder), as produced within this package.
+func Merge(rs ...*Recorder) *Recorder {
+	merged := NewRecorder()
+	for _, r := range rs {
+		if r == nil {
+			continue
+		}
+		for i, c := range r.counts {
+			merged.counts[i] += c
+		}
+		merged.totalCount += r.totalCount
+	}
+	return merged
+}
+
+// Report is a fixed summary of a Recorder's distribution, the shape
+// every latency-reporting benchmark prints.
+type Report struct {
+	Count               int64
+	P50, P90, P99, P999 time.Duration
+	Max                 time.Duration
+}
+
+// Summarize builds a Report from r.
+func Summarize(r *Recorder) Report {
+	return Report{
+		Count: r.TotalCount(),
+		P50:   r.Quantile(0.50),
+		P90:   r.Quantile(0.90),
+		P99:   r.Quantile(0.99),
+		P999:  r.Quantile(0.999),
+		Max:   r.Max(),
+	}
+}
@@ -0,0 +1,22 @@
+package latency
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteBucketsTSV writes every non-empty bucket of r as
+// "label\tvalue_ns\tcount" rows, for offline plotting (e.g. with
+// benchstat or a notebook). label identifies which recording this
+// histogram belongs to when multiple are appended to the same file.
+func WriteBucketsTSV(w io.Writer, label string, r *Recorder) error {
+	for i, c := range r.counts {
+		if c == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%d\t%d\n", label, r.valueFromIndex(i), c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
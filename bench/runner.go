@@ -0,0 +1,218 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Scenario is one named benchmark function, the unit the Runner executes
+// and reports on. fn follows the same contract as testing.B: loop
+// `for i := 0; i < b.N; i++` over the work being measured.
+type Scenario struct {
+	Name string
+	Fn   func(*B)
+}
+
+// Runner executes a set of Scenarios, repeating each Count times to
+// gather a distribution rather than a single noisy sample.
+type Runner struct {
+	Count     int       // repeats per scenario; 0 means 1
+	BenchTime BenchTime // how long/many iterations each repeat auto-sizes to
+}
+
+// Result is the aggregated outcome of running one Scenario Count times.
+// If the scenario panicked, Failed is true, Error holds the recovered
+// value, and every field other than Name is left at its zero value.
+type Result struct {
+	Name        string  `json:"name"`
+	Runs        int     `json:"runs"`
+	N           int     `json:"n"`
+	MinNs       float64 `json:"min_ns_per_op"`
+	MeanNs      float64 `json:"mean_ns_per_op"`
+	MedianNs    float64 `json:"median_ns_per_op"`
+	P95Ns       float64 `json:"p95_ns_per_op"`
+	P99Ns       float64 `json:"p99_ns_per_op"`
+	StdDevNs    float64 `json:"stddev_ns_per_op"`
+	MaxNs       float64 `json:"max_ns_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+	Failed      bool    `json:"failed,omitempty"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// Run executes every scenario Count times and returns one Result per
+// scenario, in the order given. A scenario whose Fn panics on any repeat
+// is reported as a failed Result (Failed set, Error holding the
+// recovered value) instead of aborting the run for every other
+// scenario.
+func (r *Runner) Run(scenarios []Scenario) []Result {
+	count := r.Count
+	if count <= 0 {
+		count = 1
+	}
+	target := r.BenchTime
+	if target.Duration == 0 && target.Iterations == 0 {
+		target.Duration = defaultBenchTime
+	}
+
+	results := make([]Result, 0, len(scenarios))
+	for _, s := range scenarios {
+		nsPerOp := make([]float64, count)
+		var lastN int
+		var allocsPerOp, bytesPerOp float64
+		var runErr error
+		for i := 0; i < count; i++ {
+			b, err := runOnce(s.Fn, target)
+			if err != nil {
+				runErr = err
+				break
+			}
+			nsPerOp[i] = float64(b.duration.Nanoseconds()) / float64(b.N)
+			lastN = b.N
+			mallocs, bts := b.allocs()
+			allocsPerOp += float64(mallocs) / float64(b.N)
+			bytesPerOp += float64(bts) / float64(b.N)
+		}
+		if runErr != nil {
+			results = append(results, Result{Name: s.Name, Failed: true, Error: runErr.Error()})
+			continue
+		}
+		allocsPerOp /= float64(count)
+		bytesPerOp /= float64(count)
+
+		results = append(results, Result{
+			Name:        s.Name,
+			Runs:        count,
+			N:           lastN,
+			AllocsPerOp: allocsPerOp,
+			BytesPerOp:  bytesPerOp,
+		})
+		summarize(&results[len(results)-1], nsPerOp)
+	}
+	return results
+}
+
+// defaultBenchTime is used when the Runner wasn't given an explicit
+// BenchTime, matching `go test`'s default.
+const defaultBenchTime = 1e9 // 1 second, in time.Duration's ns units
+
+// summarize fills in r's percentile and dispersion fields from the raw
+// per-run ns/op samples.
+func summarize(r *Result, samples []float64) {
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+
+	r.MinNs = sorted[0]
+	r.MaxNs = sorted[n-1]
+	r.MedianNs = percentile(sorted, 0.5)
+	r.P95Ns = percentile(sorted, 0.95)
+	r.P99Ns = percentile(sorted, 0.99)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	r.MeanNs = sum / float64(n)
+
+	var sqDiff float64
+	for _, v := range sorted {
+		d := v - r.MeanNs
+		sqDiff += d * d
+	}
+	r.StdDevNs = math.Sqrt(sqDiff / float64(n))
+}
+
+// percentile returns the q-th percentile (0..1) of a pre-sorted slice
+// using linear interpolation between the two nearest ranks.
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	idx := q * float64(len(sorted)-1)
+	lo := int(math.Floor(idx))
+	hi := int(math.Ceil(idx))
+	if lo == hi {
+		return sorted[lo]
+	}
+	frac := idx - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[hi]*frac
+}
+
+// WriteText renders results as an aligned table, for quick local reading
+// and for diffing across commits.
+func WriteText(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "name\truns\tn\tmin\tmean\tmedian\tp95\tp99\tstddev\tallocs/op\tbytes/op")
+	for _, r := range results {
+		if r.Failed {
+			fmt.Fprintf(tw, "%s\tFAILED: %s\n", r.Name, r.Error)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%.1f\t%.1f\n",
+			r.Name, r.Runs, r.N,
+			fmtNs(r.MinNs), fmtNs(r.MeanNs), fmtNs(r.MedianNs), fmtNs(r.P95Ns), fmtNs(r.P99Ns), fmtNs(r.StdDevNs),
+			r.AllocsPerOp, r.BytesPerOp)
+	}
+	return tw.Flush()
+}
+
+// WriteJSON renders results as a JSON array, for feeding into benchstat
+// or other tooling.
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// WriteCSV renders results as CSV, one row per scenario.
+func WriteCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+	header := []string{"name", "runs", "n", "min_ns_per_op", "mean_ns_per_op", "median_ns_per_op", "p95_ns_per_op", "p99_ns_per_op", "stddev_ns_per_op", "allocs_per_op", "bytes_per_op", "failed", "error"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range results {
+		row := []string{
+			r.Name,
+			strconv.Itoa(r.Runs),
+			strconv.Itoa(r.N),
+			strconv.FormatFloat(r.MinNs, 'f', -1, 64),
+			strconv.FormatFloat(r.MeanNs, 'f', -1, 64),
+			strconv.FormatFloat(r.MedianNs, 'f', -1, 64),
+			strconv.FormatFloat(r.P95Ns, 'f', -1, 64),
+			strconv.FormatFloat(r.P99Ns, 'f', -1, 64),
+			strconv.FormatFloat(r.StdDevNs, 'f', -1, 64),
+			strconv.FormatFloat(r.AllocsPerOp, 'f', -1, 64),
+			strconv.FormatFloat(r.BytesPerOp, 'f', -1, 64),
+			strconv.FormatBool(r.Failed),
+			r.Error,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fmtNs renders a nanosecond duration the way `go test -bench` does:
+// scaled to a readable unit with fixed precision.
+func fmtNs(ns float64) string {
+	switch {
+	case ns >= 1e9:
+		return fmt.Sprintf("%.2fs", ns/1e9)
+	case ns >= 1e6:
+		return fmt.Sprintf("%.2fms", ns/1e6)
+	case ns >= 1e3:
+		return fmt.Sprintf("%.2fus", ns/1e3)
+	default:
+		return fmt.Sprintf("%.0fns", ns)
+	}
+}
@@ -0,0 +1,146 @@
+// Package sched observes Go's scheduler through runtime and
+// runtime/metrics rather than its unexported internals: there's no
+// supported way to read per-P state from pure Go, so this package's
+// "per-P" picture is empirical instead of introspective. Sweep reruns a
+// workload once per GOMAXPROCS setting and reports, for each setting,
+// how many goroutines were alive and how long goroutines sat runnable
+// before they actually got to run (the runtime's own
+// "/sched/latencies:seconds" metric) — the same measurement a manual
+// GOMAXPROCS=N rerun gives, automated and diffed between steps so each
+// Sample reflects only its own step rather than everything recorded
+// since process start.
+package sched
+
+import (
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+const latenciesMetric = "/sched/latencies:seconds"
+
+// Sample is one GOMAXPROCS setting's scheduler-observability reading,
+// taken by Sweep immediately after running its workload under that
+// setting.
+type Sample struct {
+	Procs      int
+	Goroutines int
+	Latency    Report
+}
+
+// Report is a fixed summary of the scheduling-latency distribution
+// recorded during one Sweep step, the same shape as
+// bench/latency.Report.
+type Report struct {
+	Count               uint64
+	P50, P90, P99, P999 time.Duration
+	Max                 time.Duration
+}
+
+// Sweep runs workload once for every value in procs, setting GOMAXPROCS
+// to that value beforehand, and returns one Sample per step. The
+// original GOMAXPROCS is restored once every step has run.
+func Sweep(procs []int, workload func()) []Sample {
+	orig := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(orig)
+
+	samples := make([]Sample, len(procs))
+	prev := readLatencies()
+	for i, n := range procs {
+		runtime.GOMAXPROCS(n)
+		workload()
+
+		cur := readLatencies()
+		buckets, counts := diffCounts(prev, cur)
+		samples[i] = Sample{
+			Procs:      n,
+			Goroutines: runtime.NumGoroutine(),
+			Latency:    summarize(buckets, counts),
+		}
+		prev = cur
+	}
+	return samples
+}
+
+// readLatencies reads the current value of the "/sched/latencies:seconds"
+// metric: a histogram, cumulative since process start, of how long
+// goroutines spent runnable before running.
+func readLatencies() *metrics.Float64Histogram {
+	s := []metrics.Sample{{Name: latenciesMetric}}
+	metrics.Read(s)
+	return s[0].Value.Float64Histogram()
+}
+
+// diffCounts returns cur's bucket boundaries alongside its per-bucket
+// counts minus prev's, so the result reflects only events recorded
+// between the two reads. Two reads of the same metric within one
+// process always share the same boundaries, and the metric is
+// monotonic, but both are defended against here rather than assumed.
+func diffCounts(prev, cur *metrics.Float64Histogram) (buckets []float64, counts []uint64) {
+	counts = make([]uint64, len(cur.Counts))
+	for i, c := range cur.Counts {
+		var p uint64
+		if i < len(prev.Counts) {
+			p = prev.Counts[i]
+		}
+		if c > p {
+			counts[i] = c - p
+		}
+	}
+	return cur.Buckets, counts
+}
+
+// summarize builds a Report from a diffed (buckets, counts) pair, using
+// the same nearest-rank-by-cumulative-count approach as
+// bench/latency.Recorder.Quantile.
+func summarize(buckets []float64, counts []uint64) Report {
+	var total uint64
+	for _, c := range counts {
+		total += c
+	}
+	r := Report{Count: total}
+	if total == 0 {
+		return r
+	}
+	r.P50 = quantile(buckets, counts, total, 0.50)
+	r.P90 = quantile(buckets, counts, total, 0.90)
+	r.P99 = quantile(buckets, counts, total, 0.99)
+	r.P999 = quantile(buckets, counts, total, 0.999)
+	r.Max = maxBucket(buckets, counts)
+	return r
+}
+
+func quantile(buckets []float64, counts []uint64, total uint64, q float64) time.Duration {
+	target := uint64(math.Ceil(q * float64(total)))
+	var cumulative uint64
+	for i, c := range counts {
+		cumulative += c
+		if cumulative >= target {
+			return bucketDuration(buckets, i)
+		}
+	}
+	return maxBucket(buckets, counts)
+}
+
+func maxBucket(buckets []float64, counts []uint64) time.Duration {
+	for i := len(counts) - 1; i >= 0; i-- {
+		if counts[i] > 0 {
+			return bucketDuration(buckets, i)
+		}
+	}
+	return 0
+}
+
+// bucketDuration converts bucket i's lower-bound boundary (runtime/metrics
+// reports this histogram in seconds) to a time.Duration. The first and
+// last boundary can be +/-Inf per the runtime/metrics contract; those
+// are reported as 0 rather than an overflowed duration, since this
+// package only ever reports finite buckets that actually hold a count.
+func bucketDuration(buckets []float64, i int) time.Duration {
+	v := buckets[i]
+	if math.IsInf(v, 0) {
+		return 0
+	}
+	return time.Duration(v * float64(time.Second))
+}
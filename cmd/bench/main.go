@@ -0,0 +1,240 @@
+// Command bench runs the repo's Go benchmarks through the metal0/bench
+// harness and prints statistical results (min/mean/median/p95/p99/stddev,
+// allocs/op, bytes/op) in text, JSON, or CSV form. Benchmarks that record
+// per-task latency also print a p50/p90/p99/p99.9/max summary and, with
+// -hist-out, dump their full histogram buckets for offline plotting. The
+// SchedSweep benchmark instead prints one line per GOMAXPROCS setting it
+// swept, via metal0/bench/sched. With -gomaxprocs, the usual report is
+// replaced by a speedup/efficiency table: each scalable scenario
+// (SHA256Parallel, FibonacciParallel) is re-run once per setting in the
+// given list.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"metal0/bench"
+	"metal0/bench/latency"
+	"metal0/scenarios/contextswitch"
+	"metal0/scenarios/fanout"
+	"metal0/scenarios/fib"
+	"metal0/scenarios/httpbench"
+	"metal0/scenarios/jsonbench"
+	"metal0/scenarios/mockfetch"
+	"metal0/scenarios/regexbench"
+	"metal0/scenarios/schedsweep"
+	"metal0/scenarios/sha256bench"
+	"metal0/scenarios/sleep"
+	"metal0/scenarios/spawn"
+)
+
+// latencyScenario pairs a scenario name with the accessor its package
+// exposes for the latency.Recorder merged from its most recent run.
+type latencyScenario struct {
+	name   string
+	recent func() *latency.Recorder
+}
+
+var latencyScenarios = []latencyScenario{
+	{"Sleep", sleep.LastLatency},
+	{"FanOut", fanout.LastLatency},
+	{"HTTP", httpbench.LastLatency},
+	{"ContextSwitch", contextswitch.LastLatency},
+	{"MockFetch", mockfetch.LastLatency},
+}
+
+func main() {
+	count := flag.Int("count", 1, "repeat each benchmark N times")
+	benchTimeFlag := flag.String("benchtime", "1s", "run each repeat for this long (Ns) or this many iterations (Nx)")
+	output := flag.String("output", "text", "output format: text, json, or csv")
+	histOut := flag.String("hist-out", "", "dump full latency histogram buckets for latency-reporting benchmarks to this TSV file")
+	only := flag.String("only", "", "run a single scenario by name (used by cmd/metalbench to drive one scenario at a time)")
+	contract := flag.Bool("contract", false, "print a single METALBENCH tasks_per_sec=.. p50_ms=.. p99_ms=.. rss_bytes=.. line instead of the usual report, for cmd/metalbench to parse")
+	gomaxprocsFlag := flag.String("gomaxprocs", "", "comma-separated GOMAXPROCS settings to sweep (e.g. 1,2,4,8,16): re-runs each scalable CPU-bound scenario once per setting and prints a speedup/efficiency table instead of the usual report")
+	flag.Parse()
+
+	benchTime, err := bench.ParseBenchTime(*benchTimeFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	scenarios := []bench.Scenario{
+		{Name: "Spawn", Fn: spawn.Benchmark_Spawn},
+		{Name: "Sleep", Fn: sleep.Benchmark_Sleep},
+		{Name: "FanOut", Fn: fanout.Benchmark_FanOut},
+		{Name: "HTTP", Fn: httpbench.Benchmark_HTTP},
+		{Name: "ContextSwitch", Fn: contextswitch.Benchmark_ContextSwitch},
+		{Name: "MockFetch", Fn: mockfetch.Benchmark_MockFetch},
+		{Name: "SHA256Sequential", Fn: sha256bench.Benchmark_SHA256Sequential},
+		{Name: "SHA256Parallel", Fn: sha256bench.Benchmark_SHA256Parallel},
+		{Name: "Fibonacci", Fn: fib.Benchmark_Fibonacci},
+		{Name: "FibonacciTail", Fn: fib.Benchmark_FibonacciTail},
+		{Name: "FibonacciParallel", Fn: fib.Benchmark_FibonacciParallel},
+		{Name: "SchedSweep", Fn: schedsweep.Benchmark_SchedSweep},
+	}
+	scenarios = append(scenarios, jsonbench.Scenarios()...)
+	scenarios = append(scenarios, regexbench.Scenarios()...)
+
+	if *only != "" {
+		filtered := scenarios[:0]
+		for _, s := range scenarios {
+			if s.Name == *only {
+				filtered = append(filtered, s)
+			}
+		}
+		scenarios = filtered
+		if len(scenarios) == 0 {
+			fmt.Fprintf(os.Stderr, "bench: no scenario named %q\n", *only)
+			os.Exit(2)
+		}
+	}
+
+	runner := &bench.Runner{Count: *count, BenchTime: benchTime}
+
+	if *gomaxprocsFlag != "" {
+		procs, err := parseProcsList(*gomaxprocsFlag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		sweepScenarios := scalableOnly(scenarios)
+		if len(sweepScenarios) == 0 {
+			fmt.Fprintln(os.Stderr, "bench: -gomaxprocs given but no scalable scenario selected (scalable: SHA256Parallel, FibonacciParallel; narrow or drop -only)")
+			os.Exit(2)
+		}
+		if err := runGOMAXPROCSSweep(os.Stdout, runner, sweepScenarios, procs); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	results := runner.Run(scenarios)
+
+	if *contract {
+		if results[0].Failed {
+			fmt.Fprintf(os.Stderr, "bench: scenario %q failed: %s\n", results[0].Name, results[0].Error)
+			os.Exit(1)
+		}
+		printContractLine(results[0])
+		return
+	}
+
+	var writeErr error
+	switch *output {
+	case "text":
+		writeErr = bench.WriteText(os.Stdout, results)
+	case "json":
+		writeErr = bench.WriteJSON(os.Stdout, results)
+	case "csv":
+		writeErr = bench.WriteCSV(os.Stdout, results)
+	default:
+		fmt.Fprintf(os.Stderr, "bench: unknown -output %q: want text, json, or csv\n", *output)
+		os.Exit(2)
+	}
+	if writeErr != nil {
+		fmt.Fprintln(os.Stderr, writeErr)
+		os.Exit(1)
+	}
+
+	if err := reportLatency(*histOut); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	reportSchedSweep()
+}
+
+// printContractLine prints r in the common cross-runtime metrics
+// contract cmd/metalbench expects from every sibling implementation:
+// tasks/sec, p50/p99 latency in milliseconds (0 if the scenario doesn't
+// record latency), and this process's current RSS (VmRSS) in bytes.
+func printContractLine(r bench.Result) {
+	tasksPerSec := 1e9 / r.MeanNs
+
+	var p50ms, p99ms float64
+	for _, ls := range latencyScenarios {
+		if ls.name != r.Name {
+			continue
+		}
+		if rec := ls.recent(); rec != nil {
+			report := latency.Summarize(rec)
+			p50ms = float64(report.P50.Microseconds()) / 1000
+			p99ms = float64(report.P99.Microseconds()) / 1000
+		}
+		break
+	}
+
+	fmt.Printf("METALBENCH tasks_per_sec=%.2f p50_ms=%.4f p99_ms=%.4f rss_bytes=%d\n",
+		tasksPerSec, p50ms, p99ms, readRSSBytes())
+}
+
+// readRSSBytes reads this process's current resident set size from
+// /proc/self/status (Linux only; returns 0 elsewhere or on error).
+func readRSSBytes() uint64 {
+	data, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return 0
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "VmRSS:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return 0
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kb * 1024
+	}
+	return 0
+}
+
+// reportLatency prints a percentile summary for every scenario that
+// recorded a latency distribution, and appends its histogram buckets to
+// histOutPath when non-empty.
+func reportLatency(histOutPath string) error {
+	var histOut *os.File
+	if histOutPath != "" {
+		f, err := os.Create(histOutPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		histOut = f
+	}
+
+	for _, ls := range latencyScenarios {
+		rec := ls.recent()
+		if rec == nil {
+			continue
+		}
+		report := latency.Summarize(rec)
+		fmt.Printf("%s latency: n=%d p50=%s p90=%s p99=%s p99.9=%s max=%s\n",
+			ls.name, report.Count, report.P50, report.P90, report.P99, report.P999, report.Max)
+
+		if histOut != nil {
+			if err := latency.WriteBucketsTSV(histOut, ls.name, rec); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reportSchedSweep prints one line per GOMAXPROCS setting from the most
+// recent SchedSweep run, if any: live goroutine count and the scheduling-
+// latency percentiles recorded while that setting was active.
+func reportSchedSweep() {
+	for _, s := range schedsweep.LastSweep() {
+		fmt.Printf("SchedSweep procs=%d goroutines=%d sched_latency: n=%d p50=%s p90=%s p99=%s p99.9=%s max=%s\n",
+			s.Procs, s.Goroutines, s.Latency.Count, s.Latency.P50, s.Latency.P90, s.Latency.P99, s.Latency.P999, s.Latency.Max)
+	}
+}
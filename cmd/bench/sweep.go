@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"metal0/bench"
+)
+
+// scalableScenarios names the scenarios whose Fn actually fans work out
+// across goroutines (so re-running them under different GOMAXPROCS
+// settings measures real parallel scaling rather than noise) — the ones
+// -gomaxprocs sweeps.
+var scalableScenarios = map[string]bool{
+	"SHA256Parallel":    true,
+	"FibonacciParallel": true,
+}
+
+// parseProcsList parses a "-gomaxprocs" value like "1,2,4,8,16" into the
+// GOMAXPROCS settings to sweep, in the order given.
+func parseProcsList(s string) ([]int, error) {
+	fields := strings.Split(s, ",")
+	procs := make([]int, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("bench: invalid -gomaxprocs value %q: want a comma-separated list of positive integers", s)
+		}
+		procs = append(procs, n)
+	}
+	return procs, nil
+}
+
+// scalableOnly filters scenarios down to the ones scalableScenarios
+// names, preserving order.
+func scalableOnly(scenarios []bench.Scenario) []bench.Scenario {
+	var out []bench.Scenario
+	for _, s := range scenarios {
+		if scalableScenarios[s.Name] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// sweepRow is one GOMAXPROCS setting's result for one scenario, with the
+// scaling figures derived from comparing it to that scenario's result at
+// procs[0] (the sweep's baseline setting).
+type sweepRow struct {
+	Procs      int
+	MeanNs     float64
+	Speedup    float64
+	Efficiency float64 // percent; speedup / Procs * 100
+}
+
+// runGOMAXPROCSSweep re-runs each of scenarios once per setting in procs
+// (restoring the original GOMAXPROCS afterwards), and writes a
+// scaling-curve table to w: mean time, speedup relative to procs[0], and
+// parallel efficiency (speedup / procs, as a percentage) for every
+// setting, the baseline's own row included (its speedup is 1.00x).
+func runGOMAXPROCSSweep(w io.Writer, runner *bench.Runner, scenarios []bench.Scenario, procs []int) error {
+	orig := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(orig)
+
+	for _, s := range scenarios {
+		rows := make([]sweepRow, len(procs))
+		var baselineNs float64
+		for i, n := range procs {
+			runtime.GOMAXPROCS(n)
+			results := runner.Run([]bench.Scenario{s})
+			if results[0].Failed {
+				return fmt.Errorf("gomaxprocs sweep: scenario %q failed at procs=%d: %s", s.Name, n, results[0].Error)
+			}
+			meanNs := results[0].MeanNs
+			if i == 0 {
+				baselineNs = meanNs
+			}
+			speedup := baselineNs / meanNs
+			rows[i] = sweepRow{
+				Procs:      n,
+				MeanNs:     meanNs,
+				Speedup:    speedup,
+				Efficiency: speedup / float64(n) * 100,
+			}
+		}
+		if err := writeSweepTable(w, s.Name, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSweepTable renders one scenario's sweep rows as an aligned table,
+// the same tabwriter style bench.WriteText uses.
+func writeSweepTable(w io.Writer, name string, rows []sweepRow) error {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s gomaxprocs sweep:\n", name)
+	fmt.Fprintln(tw, "procs\tmean\tspeedup\tefficiency")
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%d\t%s\t%.2fx\t%.1f%%\n", r.Procs, fmtSweepNs(r.MeanNs), r.Speedup, r.Efficiency)
+	}
+	return tw.Flush()
+}
+
+// fmtSweepNs renders a nanosecond duration the same way bench.WriteText's
+// unexported fmtNs does, scaled to a readable unit with fixed precision.
+func fmtSweepNs(ns float64) string {
+	switch {
+	case ns >= 1e9:
+		return fmt.Sprintf("%.2fs", ns/1e9)
+	case ns >= 1e6:
+		return fmt.Sprintf("%.2fms", ns/1e6)
+	case ns >= 1e3:
+		return fmt.Sprintf("%.2fus", ns/1e3)
+	default:
+		return fmt.Sprintf("%.0fns", ns)
+	}
+}
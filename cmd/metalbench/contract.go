@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Metric is the common measurement every language's benchmark
+// implementation reports, regardless of what it's written in.
+type Metric struct {
+	TasksPerSec float64
+	P50Ms       float64
+	P99Ms       float64
+	RSSBytes    uint64
+}
+
+// contractLineRE matches a METALBENCH contract line, e.g.:
+//
+//	METALBENCH tasks_per_sec=123456.78 p50_ms=0.0210 p99_ms=0.1840 rss_bytes=4194304
+var contractLineRE = regexp.MustCompile(`^METALBENCH\s+tasks_per_sec=(\S+)\s+p50_ms=(\S+)\s+p99_ms=(\S+)\s+rss_bytes=(\d+)\s*$`)
+
+// ParseContract scans output for METALBENCH contract lines and returns
+// the metrics from the last one found, so an implementation is free to
+// print progress/debug output before its final result line.
+func ParseContract(output string) (Metric, error) {
+	var m Metric
+	found := false
+	for _, line := range strings.Split(output, "\n") {
+		match := contractLineRE.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		found = true
+		m.TasksPerSec, _ = strconv.ParseFloat(match[1], 64)
+		m.P50Ms, _ = strconv.ParseFloat(match[2], 64)
+		m.P99Ms, _ = strconv.ParseFloat(match[3], 64)
+		m.RSSBytes, _ = strconv.ParseUint(match[4], 10, 64)
+	}
+	if !found {
+		return Metric{}, fmt.Errorf("no METALBENCH contract line found in output")
+	}
+	return m, nil
+}
@@ -0,0 +1,78 @@
+// Command metalbench drives the same benchmark scenario across Go and
+// its sibling implementations in other languages (Python, Node, Rust,
+// ...), as declared in a manifest file, and reports a comparison matrix
+// with speedup ratios and confidence intervals from repeated runs.
+//
+// Each non-HTTP scenario's implementations must print one line of the
+// form:
+//
+//	METALBENCH tasks_per_sec=<float> p50_ms=<float> p99_ms=<float> rss_bytes=<int>
+//
+// to stdout when they finish (see cmd/bench's -contract flag for the Go
+// side). HTTP scenarios are instead driven by wrk or hey against each
+// language's server, per RunHTTPScenario.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	manifestPath := flag.String("manifest", "metalbench.toml", "scenario manifest to read")
+	runs := flag.Int("runs", 5, "repeat each language's implementation this many times")
+	output := flag.String("output", "markdown", "output format: markdown or json")
+	flag.Parse()
+
+	scenarios, err := LoadManifest(*manifestPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "metalbench:", err)
+		os.Exit(1)
+	}
+
+	var rows []Row
+	for _, s := range scenarios {
+		byLang := map[string]Aggregate{}
+
+		if s.Type == "http" {
+			for _, lang := range httpScenarioLangs(s) {
+				var samples []Metric
+				for i := 0; i < *runs; i++ {
+					m, err := RunHTTPScenario(s, lang)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "metalbench: scenario %q (%s): %v\n", s.Name, lang, err)
+						continue
+					}
+					samples = append(samples, m)
+				}
+				byLang[lang] = Summarize(samples)
+			}
+		} else {
+			for lang, cmdStr := range s.Commands {
+				warn := func(msg string) {
+					fmt.Fprintf(os.Stderr, "metalbench: scenario %q (%s): %s\n", s.Name, lang, msg)
+				}
+				samples := RunRepeated(cmdStr, *runs, warn)
+				byLang[lang] = Summarize(samples)
+			}
+		}
+
+		rows = append(rows, BuildRows(s.Name, byLang)...)
+	}
+
+	var writeErr error
+	switch *output {
+	case "markdown":
+		writeErr = WriteMarkdownMatrix(os.Stdout, rows)
+	case "json":
+		writeErr = WriteJSONMatrix(os.Stdout, rows)
+	default:
+		fmt.Fprintf(os.Stderr, "metalbench: unknown -output %q: want markdown or json\n", *output)
+		os.Exit(2)
+	}
+	if writeErr != nil {
+		fmt.Fprintln(os.Stderr, "metalbench:", writeErr)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Scenario is one row of the comparison matrix: a named benchmark plus
+// the shell command that runs each language's implementation of it.
+type Scenario struct {
+	Name     string
+	Type     string            // "generic" (default) or "http"
+	Commands map[string]string // language -> shell command, for generic scenarios
+	Fields   map[string]string // http-scenario fields: serve_<lang>, url, loadgen
+}
+
+// ParseManifest reads a scenario manifest in the small TOML subset this
+// repo uses (arrays of [[scenario]] tables, each with flat "key = value"
+// pairs and one optional [scenario.commands] sub-table). We hand-roll
+// this instead of depending on a TOML/YAML library because the rest of
+// the repo has no third-party dependencies.
+func ParseManifest(r io.Reader) ([]Scenario, error) {
+	var scenarios []Scenario
+	var cur *Scenario
+	inCommands := false
+
+	scan := bufio.NewScanner(r)
+	lineNo := 0
+	for scan.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scan.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		switch {
+		case line == "[[scenario]]":
+			scenarios = append(scenarios, Scenario{
+				Type:     "generic",
+				Commands: map[string]string{},
+				Fields:   map[string]string{},
+			})
+			cur = &scenarios[len(scenarios)-1]
+			inCommands = false
+			continue
+
+		case line == "[scenario.commands]":
+			if cur == nil {
+				return nil, fmt.Errorf("metalbench: manifest line %d: [scenario.commands] before any [[scenario]]", lineNo)
+			}
+			inCommands = true
+			continue
+		}
+
+		if cur == nil {
+			return nil, fmt.Errorf("metalbench: manifest line %d: key/value before any [[scenario]]", lineNo)
+		}
+
+		key, value, err := parseKeyValue(line)
+		if err != nil {
+			return nil, fmt.Errorf("metalbench: manifest line %d: %w", lineNo, err)
+		}
+
+		if inCommands {
+			cur.Commands[key] = value
+			continue
+		}
+		switch key {
+		case "name":
+			cur.Name = value
+		case "type":
+			cur.Type = value
+		default:
+			cur.Fields[key] = value
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	return scenarios, nil
+}
+
+// parseKeyValue parses a `key = "value"` line.
+func parseKeyValue(line string) (key, value string, err error) {
+	eq := strings.Index(line, "=")
+	if eq < 0 {
+		return "", "", fmt.Errorf("expected key = \"value\", got %q", line)
+	}
+	key = strings.TrimSpace(line[:eq])
+	raw := strings.TrimSpace(line[eq+1:])
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return "", "", fmt.Errorf("expected a quoted string value for %q, got %q", key, raw)
+	}
+	return key, raw[1 : len(raw)-1], nil
+}
+
+// LoadManifest opens and parses the manifest file at path.
+func LoadManifest(path string) ([]Scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseManifest(f)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Row is one (scenario, language) cell of the comparison matrix.
+type Row struct {
+	Scenario    string  `json:"scenario"`
+	Language    string  `json:"language"`
+	Runs        int     `json:"runs"`
+	TasksPerSec float64 `json:"tasks_per_sec"`
+	CILowTasks  float64 `json:"tasks_per_sec_ci_low"`
+	CIHighTasks float64 `json:"tasks_per_sec_ci_high"`
+	P50Ms       float64 `json:"p50_ms"`
+	P99Ms       float64 `json:"p99_ms"`
+	RSSBytes    float64 `json:"rss_bytes"`
+	SpeedupVsGo float64 `json:"speedup_vs_go"`
+}
+
+// BuildRows turns per-(scenario,language) Aggregates into Rows with a
+// speedup ratio relative to the "go" language's tasks/sec in the same
+// scenario (0 if Go wasn't measured for that scenario).
+func BuildRows(scenario string, byLang map[string]Aggregate) []Row {
+	goTasksPerSec := byLang["go"].MeanTasksPerSec
+
+	rows := make([]Row, 0, len(byLang))
+	for lang, agg := range byLang {
+		var speedup float64
+		if goTasksPerSec > 0 {
+			speedup = agg.MeanTasksPerSec / goTasksPerSec
+		}
+		rows = append(rows, Row{
+			Scenario:    scenario,
+			Language:    lang,
+			Runs:        agg.Runs,
+			TasksPerSec: agg.MeanTasksPerSec,
+			CILowTasks:  agg.CILowTasksPerSec,
+			CIHighTasks: agg.CIHighTasksPerSec,
+			P50Ms:       agg.MeanP50Ms,
+			P99Ms:       agg.MeanP99Ms,
+			RSSBytes:    agg.MeanRSSBytes,
+			SpeedupVsGo: speedup,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Language < rows[j].Language })
+	return rows
+}
+
+// WriteJSONMatrix writes the full matrix as a JSON array of Rows.
+func WriteJSONMatrix(w io.Writer, rows []Row) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
+
+// WriteMarkdownMatrix writes the matrix as a Markdown table, grouped by
+// scenario, with a Go-relative speedup column so regressions and wins
+// across languages are easy to spot in a PR diff.
+func WriteMarkdownMatrix(w io.Writer, rows []Row) error {
+	fmt.Fprintln(w, "| Scenario | Language | Tasks/sec (95% CI) | p50 (ms) | p99 (ms) | RSS | Speedup vs Go |")
+	fmt.Fprintln(w, "|---|---|---|---|---|---|---|")
+	for _, r := range rows {
+		speedup := "-"
+		if r.SpeedupVsGo > 0 {
+			speedup = fmt.Sprintf("%.2fx", r.SpeedupVsGo)
+		}
+		fmt.Fprintf(w, "| %s | %s | %.0f (%.0f–%.0f) | %.3f | %.3f | %s | %s |\n",
+			r.Scenario, r.Language, r.TasksPerSec, r.CILowTasks, r.CIHighTasks,
+			r.P50Ms, r.P99Ms, formatBytes(r.RSSBytes), speedup)
+	}
+	return nil
+}
+
+// formatBytes renders a byte count the way top/ps would (human-scaled).
+func formatBytes(b float64) string {
+	switch {
+	case b >= 1<<30:
+		return fmt.Sprintf("%.1fGB", b/(1<<30))
+	case b >= 1<<20:
+		return fmt.Sprintf("%.1fMB", b/(1<<20))
+	case b >= 1<<10:
+		return fmt.Sprintf("%.1fKB", b/(1<<10))
+	case b == 0:
+		return "-"
+	default:
+		return fmt.Sprintf("%.0fB", b)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// RunCommand runs cmdStr through the shell, capturing stdout, and parses
+// a METALBENCH contract line from it. Stderr is captured separately and
+// included in the returned error for diagnostics, but never parsed.
+func RunCommand(cmdStr string) (Metric, error) {
+	cmd := exec.Command("sh", "-c", cmdStr)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return Metric{}, fmt.Errorf("running %q: %w (stderr: %s)", cmdStr, err, stderr.String())
+	}
+
+	m, err := ParseContract(string(out))
+	if err != nil {
+		return Metric{}, fmt.Errorf("running %q: %w", cmdStr, err)
+	}
+	return m, nil
+}
+
+// RunRepeated runs cmdStr `runs` times and returns one Metric sample per
+// successful run. A failing run is logged via warn and skipped rather
+// than aborting the whole matrix, so one broken sibling implementation
+// doesn't take down the comparison for the others.
+func RunRepeated(cmdStr string, runs int, warn func(msg string)) []Metric {
+	samples := make([]Metric, 0, runs)
+	for i := 0; i < runs; i++ {
+		m, err := RunCommand(cmdStr)
+		if err != nil {
+			warn(err.Error())
+			continue
+		}
+		samples = append(samples, m)
+	}
+	return samples
+}
@@ -0,0 +1,63 @@
+package main
+
+import "math"
+
+// Aggregate summarizes repeated Metric samples for one language's
+// implementation of one scenario: a mean plus a normal-approximation 95%
+// confidence interval on tasks/sec, which is the metric that varies most
+// run-to-run.
+type Aggregate struct {
+	Runs int
+
+	MeanTasksPerSec   float64
+	CILowTasksPerSec  float64
+	CIHighTasksPerSec float64
+
+	MeanP50Ms    float64
+	MeanP99Ms    float64
+	MeanRSSBytes float64
+}
+
+// Summarize computes an Aggregate from samples. It returns the zero
+// Aggregate (Runs == 0) if samples is empty.
+func Summarize(samples []Metric) Aggregate {
+	n := len(samples)
+	if n == 0 {
+		return Aggregate{}
+	}
+
+	var sumTasks, sumP50, sumP99, sumRSS float64
+	for _, s := range samples {
+		sumTasks += s.TasksPerSec
+		sumP50 += s.P50Ms
+		sumP99 += s.P99Ms
+		sumRSS += float64(s.RSSBytes)
+	}
+	agg := Aggregate{
+		Runs:            n,
+		MeanTasksPerSec: sumTasks / float64(n),
+		MeanP50Ms:       sumP50 / float64(n),
+		MeanP99Ms:       sumP99 / float64(n),
+		MeanRSSBytes:    sumRSS / float64(n),
+	}
+
+	if n < 2 {
+		agg.CILowTasksPerSec = agg.MeanTasksPerSec
+		agg.CIHighTasksPerSec = agg.MeanTasksPerSec
+		return agg
+	}
+
+	var sqDiff float64
+	for _, s := range samples {
+		d := s.TasksPerSec - agg.MeanTasksPerSec
+		sqDiff += d * d
+	}
+	stddev := math.Sqrt(sqDiff / float64(n-1))
+	// 95% CI via the normal approximation (1.96 standard errors); a
+	// t-distribution critical value would be more correct at very low
+	// run counts, but -runs is usually >= 5 where the two are close.
+	margin := 1.96 * stddev / math.Sqrt(float64(n))
+	agg.CILowTasksPerSec = agg.MeanTasksPerSec - margin
+	agg.CIHighTasksPerSec = agg.MeanTasksPerSec + margin
+	return agg
+}
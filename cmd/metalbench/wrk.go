@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// httpScenarioLangs returns the languages an http-type scenario has a
+// server command for, derived from its "serve_<lang>" fields.
+func httpScenarioLangs(s Scenario) []string {
+	var langs []string
+	for key := range s.Fields {
+		if lang := strings.TrimPrefix(key, "serve_"); lang != key {
+			langs = append(langs, lang)
+		}
+	}
+	return langs
+}
+
+// RunHTTPScenario starts s's server for lang, waits for it to come up,
+// points wrk or hey at s.Fields["url"], and parses the load generator's
+// own reported throughput and latency percentiles (rather than trusting
+// the server's self-report) into a Metric. RSS is read from the server
+// process's /proc/<pid>/status after the load finishes.
+func RunHTTPScenario(s Scenario, lang string) (Metric, error) {
+	serveCmd, ok := s.Fields["serve_"+lang]
+	if !ok {
+		return Metric{}, fmt.Errorf("scenario %q: no serve_%s command", s.Name, lang)
+	}
+	url := s.Fields["url"]
+	if url == "" {
+		return Metric{}, fmt.Errorf("scenario %q: missing \"url\" field", s.Name)
+	}
+	loadgen := s.Fields["loadgen"]
+	if loadgen == "" {
+		loadgen = "wrk"
+	}
+
+	server := exec.Command("sh", "-c", serveCmd)
+	if err := server.Start(); err != nil {
+		return Metric{}, fmt.Errorf("scenario %q: starting %s server: %w", s.Name, lang, err)
+	}
+	defer server.Process.Kill()
+	time.Sleep(300 * time.Millisecond) // let the server bind before hammering it
+
+	var loadCmd string
+	switch loadgen {
+	case "wrk":
+		loadCmd = fmt.Sprintf("wrk -t4 -c100 -d5s --latency %s", url)
+	case "hey":
+		loadCmd = fmt.Sprintf("hey -z 5s -c 100 %s", url)
+	default:
+		return Metric{}, fmt.Errorf("scenario %q: unknown loadgen %q (want wrk or hey)", s.Name, loadgen)
+	}
+
+	out, err := exec.Command("sh", "-c", loadCmd).Output()
+	if err != nil {
+		return Metric{}, fmt.Errorf("scenario %q: running %s: %w", s.Name, loadgen, err)
+	}
+
+	m, err := parseLoadgenOutput(loadgen, string(out))
+	if err != nil {
+		return Metric{}, fmt.Errorf("scenario %q: %w", s.Name, err)
+	}
+	m.RSSBytes = readRSSBytes(server.Process.Pid)
+	return m, nil
+}
+
+var (
+	wrkReqPerSecRE = regexp.MustCompile(`Requests/sec:\s*([\d.]+)`)
+	wrkLatencyRE   = regexp.MustCompile(`(?m)^\s*(50|99)%\s+([\d.]+)(us|ms|s)\s*$`)
+
+	heyReqPerSecRE = regexp.MustCompile(`Requests/sec:\s*([\d.]+)`)
+	heyLatencyRE   = regexp.MustCompile(`(?m)^\s*(50|99)% in ([\d.]+) (usecs|secs)\s*$`)
+)
+
+// parseLoadgenOutput extracts tasks/sec and p50/p99 latency (in ms) from
+// wrk's or hey's own textual report.
+func parseLoadgenOutput(loadgen, output string) (Metric, error) {
+	switch loadgen {
+	case "wrk":
+		return parseWithRE(output, wrkReqPerSecRE, wrkLatencyRE, map[string]float64{"us": 1.0 / 1000, "ms": 1, "s": 1000})
+	case "hey":
+		return parseWithRE(output, heyReqPerSecRE, heyLatencyRE, map[string]float64{"usecs": 1.0 / 1000, "secs": 1000})
+	default:
+		return Metric{}, fmt.Errorf("unknown loadgen %q", loadgen)
+	}
+}
+
+func parseWithRE(output string, reqRE, latRE *regexp.Regexp, unitToMs map[string]float64) (Metric, error) {
+	reqMatch := reqRE.FindStringSubmatch(output)
+	if reqMatch == nil {
+		return Metric{}, fmt.Errorf("could not find Requests/sec in load generator output")
+	}
+	var m Metric
+	m.TasksPerSec, _ = strconv.ParseFloat(reqMatch[1], 64)
+
+	for _, match := range latRE.FindAllStringSubmatch(output, -1) {
+		value, _ := strconv.ParseFloat(match[2], 64)
+		ms := value * unitToMs[match[3]]
+		switch match[1] {
+		case "50":
+			m.P50Ms = ms
+		case "99":
+			m.P99Ms = ms
+		}
+	}
+	return m, nil
+}
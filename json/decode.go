@@ -0,0 +1,378 @@
+package json
+
+import "fmt"
+
+// Parse lexes and decodes data into a Value tree allocated from arena.
+// The returned Value (and everything reachable from it) is only valid
+// until the next call to arena.Reset.
+func Parse(data []byte, arena *Arena) (Value, error) {
+	toks, err := lex(data, arena)
+	if err != nil {
+		return Value{}, err
+	}
+	if len(toks) == 0 {
+		return Value{}, fmt.Errorf("json: empty input")
+	}
+	v, next, err := decodeValue(data, toks, 0, arena)
+	if err != nil {
+		return Value{}, err
+	}
+	if next != len(toks) {
+		return Value{}, fmt.Errorf("json: trailing data after top-level value at offset %d", toks[next].start)
+	}
+	return v, nil
+}
+
+// decodeValue decodes the value starting at toks[i], returning it along
+// with the index of the token just past it.
+func decodeValue(data []byte, toks []token, i int, arena *Arena) (Value, int, error) {
+	if i >= len(toks) {
+		return Value{}, 0, fmt.Errorf("json: unexpected end of input")
+	}
+
+	switch toks[i].kind {
+	case tokObjectOpen:
+		return decodeObject(data, toks, i, arena)
+	case tokArrayOpen:
+		return decodeArray(data, toks, i, arena)
+	case tokString:
+		return Value{kind: KindString, raw: data[toks[i].start:toks[i].end]}, i + 1, nil
+	case tokNumber:
+		return Value{kind: KindNumber, raw: data[toks[i].start:toks[i].end]}, i + 1, nil
+	case tokTrue, tokFalse:
+		return Value{kind: KindBool, raw: data[toks[i].start:toks[i].end]}, i + 1, nil
+	case tokNull:
+		return Value{kind: KindNull}, i + 1, nil
+	default:
+		return Value{}, 0, fmt.Errorf("json: unexpected token at offset %d", toks[i].start)
+	}
+}
+
+// countArrayElems returns the number of top-level elements in the array
+// whose opening bracket is toks[i], skipping each one with skipValue
+// rather than decoding it — just enough work to size the arena slice
+// decodeArray fills on its second pass.
+func countArrayElems(toks []token, i int) (count int, err error) {
+	i++ // consume '['
+	for {
+		if i >= len(toks) {
+			return 0, fmt.Errorf("json: unterminated array")
+		}
+		if toks[i].kind == tokArrayClose {
+			return count, nil
+		}
+		if count > 0 {
+			if toks[i].kind != tokComma {
+				return 0, fmt.Errorf("json: expected ',' or ']' at offset %d", toks[i].start)
+			}
+			i++
+		}
+		i, err = skipValue(toks, i)
+		if err != nil {
+			return 0, err
+		}
+		count++
+	}
+}
+
+// decodeArray decodes the array whose opening bracket is toks[i]. It
+// scans the array twice: once via countArrayElems to size an
+// arena-allocated slice, once to decode each element directly into that
+// slice, so no intermediate Go slice is built and copied in.
+func decodeArray(data []byte, toks []token, i int, arena *Arena) (Value, int, error) {
+	count, err := countArrayElems(toks, i)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	children := arena.allocValues(count)
+
+	j := i + 1 // consume '['
+	for idx := 0; idx < count; idx++ {
+		if idx > 0 {
+			j++ // comma, already validated by countArrayElems
+		}
+		var v Value
+		v, j, err = decodeValue(data, toks, j, arena)
+		if err != nil {
+			return Value{}, 0, err
+		}
+		children[idx] = v
+	}
+	j++ // consume ']'
+	return Value{kind: KindArray, children: children}, j, nil
+}
+
+// countObjectPairs returns the number of top-level key/value pairs in
+// the object whose opening brace is toks[i], the object analogue of
+// countArrayElems.
+func countObjectPairs(toks []token, i int) (count int, err error) {
+	i++ // consume '{'
+	for {
+		if i >= len(toks) {
+			return 0, fmt.Errorf("json: unterminated object")
+		}
+		if toks[i].kind == tokObjectClose {
+			return count, nil
+		}
+		if count > 0 {
+			if toks[i].kind != tokComma {
+				return 0, fmt.Errorf("json: expected ',' or '}' at offset %d", toks[i].start)
+			}
+			i++
+		}
+		if i >= len(toks) {
+			return 0, fmt.Errorf("json: unterminated object")
+		}
+		if toks[i].kind != tokString {
+			return 0, fmt.Errorf("json: expected object key at offset %d", toks[i].start)
+		}
+		i++
+		if i >= len(toks) {
+			return 0, fmt.Errorf("json: unterminated object")
+		}
+		if toks[i].kind != tokColon {
+			return 0, fmt.Errorf("json: expected ':' at offset %d", toks[i].start)
+		}
+		i++
+		i, err = skipValue(toks, i)
+		if err != nil {
+			return 0, err
+		}
+		count++
+	}
+}
+
+// decodeObject decodes the object whose opening brace is toks[i]. Like
+// decodeArray, it scans the object twice: once via countObjectPairs to
+// size arena-allocated key/value slices, once to fill them directly.
+func decodeObject(data []byte, toks []token, i int, arena *Arena) (Value, int, error) {
+	count, err := countObjectPairs(toks, i)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	keySlots := arena.allocKeys(count)
+	valSlots := arena.allocValues(count)
+
+	j := i + 1 // consume '{'
+	for idx := 0; idx < count; idx++ {
+		if idx > 0 {
+			j++ // comma, already validated by countObjectPairs
+		}
+		keySlots[idx] = data[toks[j].start:toks[j].end]
+		j += 2 // consume key and ':', already validated by countObjectPairs
+		var v Value
+		v, j, err = decodeValue(data, toks, j, arena)
+		if err != nil {
+			return Value{}, 0, err
+		}
+		valSlots[idx] = v
+	}
+	j++ // consume '}'
+	return Value{kind: KindObject, keys: keySlots, children: valSlots}, j, nil
+}
+
+// Get looks up a dotted path (e.g. "users.0.name") in the top-level JSON
+// value encoded by data, without materializing any sibling it doesn't
+// need to descend through. Array indices are path segments that parse
+// as a non-negative integer. arena is used the same way as in Parse, to
+// materialize the value the path resolves to (which may itself be a
+// container).
+func Get(data []byte, path string, arena *Arena) (Value, error) {
+	toks, err := lex(data, arena)
+	if err != nil {
+		return Value{}, err
+	}
+	if len(toks) == 0 {
+		return Value{}, fmt.Errorf("json: empty input")
+	}
+
+	segs := splitPath(path)
+	i := 0
+	for _, seg := range segs {
+		switch toks[i].kind {
+		case tokObjectOpen:
+			found, ok, err := findObjectKey(data, toks, i, seg)
+			if err != nil {
+				return Value{}, err
+			}
+			if !ok {
+				return Value{}, fmt.Errorf("json: key %q not found", seg)
+			}
+			i = found
+		case tokArrayOpen:
+			idx, err := parseIndex(seg)
+			if err != nil {
+				return Value{}, err
+			}
+			found, ok, err := findArrayIndex(data, toks, i, idx)
+			if err != nil {
+				return Value{}, err
+			}
+			if !ok {
+				return Value{}, fmt.Errorf("json: index %d out of range", idx)
+			}
+			i = found
+		default:
+			return Value{}, fmt.Errorf("json: path segment %q has no container to descend into", seg)
+		}
+	}
+
+	v, _, err := decodeValue(data, toks, i, arena)
+	return v, err
+}
+
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	var segs []string
+	start := 0
+	for i := 0; i <= len(path); i++ {
+		if i == len(path) || path[i] == '.' {
+			segs = append(segs, path[start:i])
+			start = i + 1
+		}
+	}
+	return segs
+}
+
+func parseIndex(seg string) (int, error) {
+	n := 0
+	if seg == "" {
+		return 0, fmt.Errorf("json: empty array index")
+	}
+	for _, c := range seg {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("json: invalid array index %q", seg)
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, nil
+}
+
+// findObjectKey scans the object whose opening brace is toks[objStart]
+// for a key matching want, skipping every other key's value with
+// skipValue instead of decoding it. It returns the index of the token
+// where the matching key's value begins.
+func findObjectKey(data []byte, toks []token, objStart int, want string) (valStart int, ok bool, err error) {
+	i := objStart + 1
+	for {
+		if i >= len(toks) {
+			return 0, false, fmt.Errorf("json: unterminated object")
+		}
+		if toks[i].kind == tokObjectClose {
+			return 0, false, nil
+		}
+		if toks[i].kind == tokComma {
+			i++
+			continue
+		}
+		if toks[i].kind != tokString {
+			return 0, false, fmt.Errorf("json: expected object key at offset %d", toks[i].start)
+		}
+		key := data[toks[i].start:toks[i].end]
+		i++
+		if i >= len(toks) {
+			return 0, false, fmt.Errorf("json: unterminated object")
+		}
+		if toks[i].kind != tokColon {
+			return 0, false, fmt.Errorf("json: expected ':' at offset %d", toks[i].start)
+		}
+		i++
+		matched, err := keyEquals(key, want)
+		if err != nil {
+			return 0, false, err
+		}
+		if matched {
+			return i, true, nil
+		}
+		i, err = skipValue(toks, i)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+}
+
+// findArrayIndex scans the array whose opening bracket is toks[arrStart]
+// for its want'th element, skipping every earlier element with
+// skipValue instead of decoding it.
+func findArrayIndex(data []byte, toks []token, arrStart int, want int) (valStart int, ok bool, err error) {
+	i := arrStart + 1
+	n := 0
+	for {
+		if i >= len(toks) {
+			return 0, false, fmt.Errorf("json: unterminated array")
+		}
+		if toks[i].kind == tokArrayClose {
+			return 0, false, nil
+		}
+		if toks[i].kind == tokComma {
+			i++
+			continue
+		}
+		if n == want {
+			return i, true, nil
+		}
+		i, err = skipValue(toks, i)
+		if err != nil {
+			return 0, false, err
+		}
+		n++
+	}
+}
+
+// keyEquals reports whether key (a raw string token, quotes included and
+// possibly containing escapes) names want.
+func keyEquals(key []byte, want string) (bool, error) {
+	s, err := unescapeJSONString(key)
+	if err != nil {
+		return false, err
+	}
+	return s == want, nil
+}
+
+// skipValue advances past the value starting at toks[i] without
+// decoding it, using matchingClose to jump straight over a nested
+// array/object's children.
+func skipValue(toks []token, i int) (int, error) {
+	if i >= len(toks) {
+		return 0, fmt.Errorf("json: unexpected end of input")
+	}
+	switch toks[i].kind {
+	case tokObjectOpen, tokArrayOpen:
+		return matchingClose(toks, i)
+	default:
+		return i + 1, nil
+	}
+}
+
+// matchingClose returns the index just past the closing bracket/brace
+// that matches the open one at toks[i], tracking nesting depth so it
+// skips over any children's own brackets rather than stopping at the
+// first close it sees.
+func matchingClose(toks []token, i int) (int, error) {
+	open := toks[i].kind
+	closeKind := tokObjectClose
+	if open == tokArrayOpen {
+		closeKind = tokArrayClose
+	}
+
+	depth := 1
+	i++
+	for i < len(toks) {
+		switch toks[i].kind {
+		case tokObjectOpen, tokArrayOpen:
+			depth++
+		case tokObjectClose, tokArrayClose:
+			depth--
+			if depth == 0 {
+				if toks[i].kind != closeKind {
+					return 0, fmt.Errorf("json: mismatched bracket at offset %d", toks[i].start)
+				}
+				return i + 1, nil
+			}
+		}
+		i++
+	}
+	return 0, fmt.Errorf("json: unterminated container")
+}
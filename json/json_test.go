@@ -0,0 +1,156 @@
+package json
+
+import (
+	"reflect"
+	"testing"
+)
+
+func mustParse(t *testing.T, data string) (Value, *Arena) {
+	t.Helper()
+	arena := NewArena(16, 16)
+	v, err := Parse([]byte(data), arena)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", data, err)
+	}
+	return v, arena
+}
+
+func TestParseScalars(t *testing.T) {
+	v, _ := mustParse(t, `42`)
+	if v.Kind() != KindNumber {
+		t.Fatalf("Kind() = %v, want KindNumber", v.Kind())
+	}
+	n, err := v.Number()
+	if err != nil || n != 42 {
+		t.Fatalf("Number() = %v, %v, want 42, nil", n, err)
+	}
+
+	v, _ = mustParse(t, `"hi"`)
+	s, err := v.String()
+	if err != nil || s != "hi" {
+		t.Fatalf("String() = %q, %v, want %q, nil", s, err, "hi")
+	}
+
+	v, _ = mustParse(t, `true`)
+	if !v.Bool() {
+		t.Fatalf("Bool() = false, want true")
+	}
+
+	v, _ = mustParse(t, `null`)
+	if v.Kind() != KindNull {
+		t.Fatalf("Kind() = %v, want KindNull", v.Kind())
+	}
+}
+
+func TestParseNestedContainers(t *testing.T) {
+	v, _ := mustParse(t, `{"a":1,"b":[1,2,3,{"c":"hello","d":[true,false,null]}],"e":"world"}`)
+	if v.Kind() != KindObject || v.Len() != 3 {
+		t.Fatalf("top-level: kind=%v len=%d, want KindObject len=3", v.Kind(), v.Len())
+	}
+	if k, _ := v.Key(0); k != "a" {
+		t.Fatalf("Key(0) = %q, want %q", k, "a")
+	}
+
+	arr := v.Elem(1)
+	if arr.Kind() != KindArray || arr.Len() != 4 {
+		t.Fatalf("b: kind=%v len=%d, want KindArray len=4", arr.Kind(), arr.Len())
+	}
+
+	nested := arr.Index(3)
+	if nested.Kind() != KindObject || nested.Len() != 2 {
+		t.Fatalf("b[3]: kind=%v len=%d, want KindObject len=2", nested.Kind(), nested.Len())
+	}
+	if k, _ := nested.Key(0); k != "c" {
+		t.Fatalf("b[3] Key(0) = %q, want %q", k, "c")
+	}
+	if s, _ := nested.Elem(0).String(); s != "hello" {
+		t.Fatalf("b[3].c = %q, want %q", s, "hello")
+	}
+
+	d := nested.Elem(1)
+	if d.Len() != 3 || !d.Index(0).Bool() || d.Index(1).Bool() || d.Index(2).Kind() != KindNull {
+		t.Fatalf("b[3].d = unexpected shape")
+	}
+}
+
+func TestParseEmptyContainers(t *testing.T) {
+	v, _ := mustParse(t, `{"a":[],"b":{}}`)
+	if v.Elem(0).Len() != 0 || v.Elem(0).Kind() != KindArray {
+		t.Fatalf("a: want empty array")
+	}
+	if v.Elem(1).Len() != 0 || v.Elem(1).Kind() != KindObject {
+		t.Fatalf("b: want empty object")
+	}
+}
+
+func TestParseReusesArenaAcrossCalls(t *testing.T) {
+	arena := NewArena(4, 4)
+	data := []byte(`{"x":[1,2,3]}`)
+	for i := 0; i < 5; i++ {
+		arena.Reset()
+		v, err := Parse(data, arena)
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		if v.Elem(0).Len() != 3 {
+			t.Fatalf("iteration %d: x len = %d, want 3", i, v.Elem(0).Len())
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	data := []byte(`{"a":1,"b":[1,2,3,{"c":"hello"}],"e":"world"}`)
+	arena := NewArena(16, 16)
+
+	v, err := Get(data, "b.3.c", arena)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if s, _ := v.String(); s != "hello" {
+		t.Fatalf("Get(b.3.c) = %q, want %q", s, "hello")
+	}
+
+	v, err = Get(data, "e", arena)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if s, _ := v.String(); s != "world" {
+		t.Fatalf("Get(e) = %q, want %q", s, "world")
+	}
+
+	if _, err := Get(data, "nope", arena); err == nil {
+		t.Fatalf("Get(nope): want error, got nil")
+	}
+}
+
+func TestParseMalformedInput(t *testing.T) {
+	cases := []string{
+		`{"a":1,}`,
+		`[1,2,`,
+		`{"a":}`,
+		``,
+		`{"a" 1}`,
+	}
+	for _, c := range cases {
+		arena := NewArena(4, 4)
+		if _, err := Parse([]byte(c), arena); err == nil {
+			t.Errorf("Parse(%q): want error, got nil", c)
+		}
+	}
+}
+
+func TestParseAgreesWithFieldOrder(t *testing.T) {
+	v, _ := mustParse(t, `{"first":1,"second":2,"third":3}`)
+	var keys []string
+	for i := 0; i < v.Len(); i++ {
+		k, err := v.Key(i)
+		if err != nil {
+			t.Fatalf("Key(%d): %v", i, err)
+		}
+		keys = append(keys, k)
+	}
+	want := []string{"first", "second", "third"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Fatalf("keys = %v, want %v", keys, want)
+	}
+}
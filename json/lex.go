@@ -0,0 +1,172 @@
+package json
+
+import "fmt"
+
+// tokenKind identifies what a token (a single position in the
+// structural index) starts.
+type tokenKind int
+
+const (
+	tokObjectOpen tokenKind = iota
+	tokObjectClose
+	tokArrayOpen
+	tokArrayClose
+	tokComma
+	tokColon
+	tokString
+	tokNumber
+	tokTrue
+	tokFalse
+	tokNull
+)
+
+// token is one entry in the flat token list lex produces: its kind and
+// the byte range in the source it spans. Structural tokens (braces,
+// brackets, comma, colon) have start+1 == end; string/number/literal
+// tokens span their full raw bytes, unescaped lazily by Value.String
+// and Value.Number.
+type token struct {
+	kind       tokenKind
+	start, end int
+}
+
+// lex scans data into a flat token list, the "structural index" the
+// package doc describes: one pass over the bytes, classifying whole
+// 8-byte words at a time with skipWhitespace/scanBareLiteral wherever a
+// run of non-structural bytes allows it, falling back to one byte at a
+// time only at the edges of those runs and inside strings. The returned
+// slice is backed by arena.tokens (reused across calls, growing only
+// when a larger document demands it), not a fresh allocation.
+func lex(data []byte, arena *Arena) ([]token, error) {
+	toks := arena.tokens[:0]
+	i := 0
+	for {
+		i = skipWhitespace(data, i)
+		i = skipWhitespaceByte(data, i)
+		if i >= len(data) {
+			arena.tokens = toks
+			return toks, nil
+		}
+
+		switch c := data[i]; {
+		case c == '{':
+			toks = append(toks, token{tokObjectOpen, i, i + 1})
+			i++
+		case c == '}':
+			toks = append(toks, token{tokObjectClose, i, i + 1})
+			i++
+		case c == '[':
+			toks = append(toks, token{tokArrayOpen, i, i + 1})
+			i++
+		case c == ']':
+			toks = append(toks, token{tokArrayClose, i, i + 1})
+			i++
+		case c == ',':
+			toks = append(toks, token{tokComma, i, i + 1})
+			i++
+		case c == ':':
+			toks = append(toks, token{tokColon, i, i + 1})
+			i++
+		case c == '"':
+			end, err := scanString(data, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{tokString, i, end})
+			i = end
+		case c == 't' || c == 'f' || c == 'n':
+			kind, end, err := scanKeyword(data, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind, i, end})
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			// No boundary byte (see isBoundaryByte/boundaryMask) appears
+			// inside a number's digits, '.', 'e'/'E', or sign, so one
+			// scanBareLiteralEnd call consumes its integer, fraction, and
+			// exponent parts together.
+			end := scanBareLiteralEnd(data, i+1)
+			toks = append(toks, token{tokNumber, i, end})
+			i = end
+		default:
+			return nil, fmt.Errorf("json: unexpected byte %q at offset %d", c, i)
+		}
+	}
+}
+
+// skipWhitespaceByte advances past any whitespace bytes remaining after
+// skipWhitespace's word-at-a-time pass stops short of one (the tail of
+// data, or a word that wasn't all whitespace).
+func skipWhitespaceByte(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// scanBareLiteralEnd finds the end of the bare literal (digits of a
+// number, or a true/false/null keyword) starting at data[i]: a
+// scanBareLiteral fast pass over whole 8-byte words that don't contain a
+// boundary byte, finished off one byte at a time for the partial word
+// bulkSkipWhile always leaves for its caller (see its doc comment).
+func scanBareLiteralEnd(data []byte, i int) int {
+	i = scanBareLiteral(data, i)
+	for i < len(data) && !isBoundaryByte(data[i]) {
+		i++
+	}
+	return i
+}
+
+// isBoundaryByte is the byte-at-a-time equivalent of boundaryMask: it
+// reports whether b can never appear inside a bare literal.
+func isBoundaryByte(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\r', '{', '}', '[', ']', ',', ':', '"':
+		return true
+	default:
+		return false
+	}
+}
+
+// scanString advances past the string token starting at data[start]
+// (which must be '"'), returning the offset just past its closing quote.
+// It does not unescape: that happens on demand in Value.String.
+func scanString(data []byte, start int) (int, error) {
+	i := start + 1
+	for i < len(data) {
+		switch data[i] {
+		case '"':
+			return i + 1, nil
+		case '\\':
+			i += 2
+		default:
+			i++
+		}
+	}
+	return 0, fmt.Errorf("json: unterminated string starting at offset %d", start)
+}
+
+// scanKeyword advances past the bare-word literal (true, false, or
+// null) starting at data[start].
+func scanKeyword(data []byte, start int) (tokenKind, int, error) {
+	for _, kw := range []struct {
+		lit  string
+		kind tokenKind
+	}{
+		{"true", tokTrue},
+		{"false", tokFalse},
+		{"null", tokNull},
+	} {
+		end := start + len(kw.lit)
+		if end <= len(data) && string(data[start:end]) == kw.lit {
+			return kw.kind, end, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("json: invalid literal at offset %d", start)
+}
@@ -0,0 +1,15 @@
+package json
+
+// skipWhitespace and scanBareLiteral are the structural scanner's
+// word-at-a-time entry points, built on the portable SWAR bit tricks in
+// swar.go: 8 bytes classified per iteration instead of one byte per
+// branch. A real SIMD path (AVX2's VPCMPEQB/VPMOVMSKB, classifying a
+// 32-byte lane per instruction) would go further on amd64, but isn't
+// implemented here.
+func skipWhitespace(data []byte, i int) int {
+	return bulkSkipWhile(data, i, wordAllWhitespace)
+}
+
+func scanBareLiteral(data []byte, i int) int {
+	return bulkSkipWhile(data, i, wordNoBoundary)
+}
@@ -0,0 +1,85 @@
+package json
+
+// This file implements the structural-byte classification with SWAR
+// (SIMD-within-a-register) bit tricks: each 8-byte word is classified in
+// a handful of arithmetic/logical ops instead of a per-byte branch, using
+// the carry-free form of the classic bit-twiddling-hacks haszero() idiom,
+// generalized to mark exactly the lanes holding an arbitrary byte across
+// all 8 lanes at once.
+
+const (
+	loBits  = 0x0101010101010101
+	hiBits  = 0x8080808080808080
+	allHigh = hiBits // every lane's high bit set = "all 8 lanes matched"
+)
+
+func broadcastByte(b byte) uint64 { return loBits * uint64(b) }
+
+// eqMaskBytes returns a word with the high bit of each byte lane set
+// where that lane of v equals b, and clear otherwise.
+func eqMaskBytes(v uint64, b byte) uint64 {
+	x := v ^ broadcastByte(b)
+	// Adding 0x7F to a lane's low 7 bits sets its high bit unless they are
+	// all zero, and never carries into the next lane.
+	t := (x &^ hiBits) + loBits*0x7F
+	return ^(t | x | loBits*0x7F)
+}
+
+// whitespaceMask ORs together the per-lane match masks for the four
+// whitespace bytes JSON allows between tokens.
+func whitespaceMask(v uint64) uint64 {
+	return eqMaskBytes(v, ' ') | eqMaskBytes(v, '\t') | eqMaskBytes(v, '\n') | eqMaskBytes(v, '\r')
+}
+
+// structuralOrQuoteMask ORs together the per-lane match masks for every
+// byte that starts or ends a structural token: `{ } [ ] , : "`.
+func structuralOrQuoteMask(v uint64) uint64 {
+	return eqMaskBytes(v, '{') | eqMaskBytes(v, '}') |
+		eqMaskBytes(v, '[') | eqMaskBytes(v, ']') |
+		eqMaskBytes(v, ',') | eqMaskBytes(v, ':') |
+		eqMaskBytes(v, '"')
+}
+
+// boundaryMask is whitespaceMask | structuralOrQuoteMask: the set of
+// bytes that can never appear inside a bare literal (number, true,
+// false, null), so a lane matching it always ends such a token.
+func boundaryMask(v uint64) uint64 {
+	return whitespaceMask(v) | structuralOrQuoteMask(v)
+}
+
+func allLanesMatch(mask uint64) bool { return mask == allHigh }
+func noLanesMatch(mask uint64) bool  { return mask == 0 }
+
+// wordAllWhitespace reports whether every byte lane of w is a JSON
+// whitespace byte.
+func wordAllWhitespace(w uint64) bool { return allLanesMatch(whitespaceMask(w)) }
+
+// wordNoBoundary reports whether no byte lane of w would end a bare
+// literal (number, true, false, null).
+func wordNoBoundary(w uint64) bool { return noLanesMatch(boundaryMask(w)) }
+
+const wordSize = 8
+
+// bulkSkipWhile advances past data[i:] eight bytes at a time for as long
+// as classifyWord reports every lane of the word satisfies the
+// condition, falling back to the caller for the first word that
+// doesn't (or for a final partial word at the end of data). It's the
+// building block skipWhitespace and scanBareLiteral in scan.go use, the
+// "structural index, 64-byte-chunk" scan the package doc describes
+// scaled down to one 8-byte word per call.
+func bulkSkipWhile(data []byte, i int, wordSatisfies func(uint64) bool) int {
+	for i+wordSize <= len(data) {
+		w := leUint64(data[i : i+wordSize])
+		if !wordSatisfies(w) {
+			break
+		}
+		i += wordSize
+	}
+	return i
+}
+
+func leUint64(b []byte) uint64 {
+	_ = b[7]
+	return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+}
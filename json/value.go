@@ -0,0 +1,199 @@
+// Package json is a two-stage JSON parser built to avoid the two things
+// that dominate encoding/json's cost when unmarshaling into
+// interface{}: reflection and per-node heap allocation.
+//
+// Stage one (lex.go) scans the input once, classifying bytes with the
+// SWAR bit tricks in swar.go instead of a per-byte switch wherever
+// possible, and produces a flat list of tokens (structural-index
+// positions, in simdjson's terms) into a buffer owned by the caller's
+// Arena, reused across calls the same way the Arena's Value/key storage
+// is.
+//
+// Stage two (decode.go) walks that token list twice per array/object:
+// once (via skipValue) to count its children, once to decode them
+// directly into a single arena-allocated slice sized from that count —
+// no intermediate Go slice is built and copied in. Reusing the same
+// Arena (via Arena.Reset) across repeated Parse calls on similarly-sized
+// documents means only the first call actually grows anything — every
+// call after that parses with zero new heap allocations.
+//
+// Get performs a point query directly over the token list, skipping
+// sibling subtrees with matchingClose/skipValue rather than
+// materializing them, so looking up one field of a large document is
+// cheaper than a full Parse.
+package json
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind identifies what a Value holds.
+type Kind int
+
+const (
+	KindNull Kind = iota
+	KindBool
+	KindNumber
+	KindString
+	KindArray
+	KindObject
+)
+
+// Value is a JSON value materialized into an Arena. The zero Value is
+// not meaningful on its own; Values are only produced by Parse or Get.
+type Value struct {
+	kind     Kind
+	raw      []byte  // Null/Bool/Number/String: the raw token bytes (strings include their quotes)
+	children []Value // Array: each element. Object: each value, parallel to keys.
+	keys     [][]byte
+}
+
+// Kind reports v's JSON type.
+func (v Value) Kind() Kind { return v.kind }
+
+// Bool returns v's boolean value. It's only meaningful when Kind() ==
+// KindBool.
+func (v Value) Bool() bool { return len(v.raw) > 0 && v.raw[0] == 't' }
+
+// Number parses v's numeric value. It's only meaningful when Kind() ==
+// KindNumber.
+func (v Value) Number() (float64, error) {
+	return strconv.ParseFloat(string(v.raw), 64)
+}
+
+// String unescapes v's string value. It's only meaningful when Kind()
+// == KindString. Unescaping is done here, on demand, rather than during
+// Parse, so a document whose string fields are never read never pays
+// for decoding them.
+func (v Value) String() (string, error) {
+	return unescapeJSONString(v.raw)
+}
+
+// Len returns the number of elements in an array or key/value pairs in
+// an object. It's only meaningful when Kind() is KindArray or
+// KindObject.
+func (v Value) Len() int { return len(v.children) }
+
+// Index returns the i'th element of an array. It's only meaningful
+// when Kind() == KindArray.
+func (v Value) Index(i int) Value { return v.children[i] }
+
+// Key returns the unescaped name of the i'th key/value pair of an
+// object. It's only meaningful when Kind() == KindObject.
+func (v Value) Key(i int) (string, error) { return unescapeJSONString(v.keys[i]) }
+
+// Elem returns the i'th value of an object, parallel to Key(i). It's
+// only meaningful when Kind() == KindObject.
+func (v Value) Elem(i int) Value { return v.children[i] }
+
+// Arena is a bump allocator for Value trees: Parse and Get hand out
+// slices from it instead of allocating each array/object's children
+// individually. Reset reclaims all previously allocated space for
+// reuse without returning it to the Go runtime, so repeatedly Parsing
+// similarly-sized documents into the same Arena (Reset between calls)
+// does not grow the heap after the first couple of calls. tokens is the
+// same kind of reused buffer for lex's flat token list, owned by the
+// Arena so it amortizes the same way values/keys do; it has no Len
+// counterpart because lex always rebuilds it from scratch each call
+// (unlike values/keys, which accumulate across nested decodeArray/
+// decodeObject calls within one Parse).
+type Arena struct {
+	values    []Value
+	valuesLen int
+	keys      [][]byte
+	keysLen   int
+	tokens    []token
+}
+
+// NewArena creates an Arena pre-sized to hold valueCap Values and
+// keyCap object keys before it needs to grow.
+func NewArena(valueCap, keyCap int) *Arena {
+	return &Arena{values: make([]Value, valueCap), keys: make([][]byte, keyCap)}
+}
+
+// Reset discards everything previously allocated from a, making its
+// full capacity available again.
+func (a *Arena) Reset() {
+	a.valuesLen = 0
+	a.keysLen = 0
+}
+
+func (a *Arena) allocValues(n int) []Value {
+	if a.valuesLen+n > len(a.values) {
+		grown := make([]Value, (len(a.values)+n)*2)
+		copy(grown, a.values[:a.valuesLen])
+		a.values = grown
+	}
+	s := a.values[a.valuesLen : a.valuesLen+n : a.valuesLen+n]
+	a.valuesLen += n
+	return s
+}
+
+func (a *Arena) allocKeys(n int) [][]byte {
+	if a.keysLen+n > len(a.keys) {
+		grown := make([][]byte, (len(a.keys)+n)*2)
+		copy(grown, a.keys[:a.keysLen])
+		a.keys = grown
+	}
+	s := a.keys[a.keysLen : a.keysLen+n : a.keysLen+n]
+	a.keysLen += n
+	return s
+}
+
+// unescapeJSONString decodes raw (a string token including its
+// surrounding quotes) into a Go string. It does not combine surrogate
+// pairs from \u escapes outside the Basic Multilingual Plane into a
+// single rune; that's a corner this engine doesn't cover.
+func unescapeJSONString(raw []byte) (string, error) {
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return "", fmt.Errorf("json: malformed string token %q", raw)
+	}
+	inner := raw[1 : len(raw)-1]
+	if bytes.IndexByte(inner, '\\') == -1 {
+		return string(inner), nil
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(inner))
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		if c != '\\' {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(inner) {
+			return "", fmt.Errorf("json: truncated escape in string")
+		}
+		switch inner[i] {
+		case '"', '\\', '/':
+			sb.WriteByte(inner[i])
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'u':
+			if i+4 >= len(inner) {
+				return "", fmt.Errorf("json: truncated \\u escape")
+			}
+			code, err := strconv.ParseUint(string(inner[i+1:i+5]), 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("json: invalid \\u escape: %w", err)
+			}
+			sb.WriteRune(rune(code))
+			i += 4
+		default:
+			return "", fmt.Errorf("json: invalid escape \\%c", inner[i])
+		}
+	}
+	return sb.String(), nil
+}
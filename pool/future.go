@@ -0,0 +1,50 @@
+package pool
+
+import "fmt"
+
+// Future is the result of a SubmitTyped call: a value that becomes
+// available once its task finishes.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Get blocks until the task has finished and returns its result, or the
+// error recovered from a panic inside it.
+func (f *Future[T]) Get() (T, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// SubmitTyped queues fn on p and returns a Future for its result. Unlike
+// Submit, a panicking fn does not invoke p's panic handler: the panic is
+// instead captured and returned from Future.Get, since the caller is
+// waiting for a result anyway.
+func SubmitTyped[T any](p *Pool, fn func() T) (*Future[T], error) {
+	return submitTyped(func(*Worker) T { return fn() }, p.Submit)
+}
+
+// SubmitTypedToWorker is SubmitTyped pinned to a specific worker: fn
+// receives the Worker executing it, for access to per-worker state such
+// as a latency.Recorder indexed by Worker.ID().
+func SubmitTypedToWorker[T any](p *Pool, workerIdx int, fn func(w *Worker) T) (*Future[T], error) {
+	return submitTyped(fn, func(task Task) error { return p.SubmitToWorker(workerIdx, task) })
+}
+
+func submitTyped[T any](fn func(w *Worker) T, submit func(Task) error) (*Future[T], error) {
+	f := &Future[T]{done: make(chan struct{})}
+	err := submit(func(w *Worker) {
+		defer close(f.done)
+		defer func() {
+			if r := recover(); r != nil {
+				f.err = fmt.Errorf("pool: task panicked: %v", r)
+			}
+		}()
+		f.value = fn(w)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
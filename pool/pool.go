@@ -0,0 +1,271 @@
+// Package pool provides a bounded worker pool to replace the
+// `go func() { ... }` + sync.WaitGroup + buffered-channel pattern used
+// throughout this repo's benchmarks. A Pool runs a fixed number of
+// long-lived workers (default runtime.NumCPU()), each with its own
+// sync.Pool-backed scratch buffer, recovers panics so one bad task can't
+// take down a worker, and can optionally let idle workers steal queued
+// work from their peers.
+package pool
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// stealRetryInterval bounds how long a worker with stealing enabled
+// waits on its own queue before trying to steal from peers again. Without
+// this, a worker that finds every peer queue empty on its first steal
+// attempt would fall back to blocking on own indefinitely, never
+// revisiting peers even if they later pile up work (e.g. all of it
+// pinned to one worker via SubmitToWorker).
+const stealRetryInterval = 100 * time.Microsecond
+
+// ErrClosed is returned by Submit/SubmitToWorker once the Pool has been
+// closed.
+var ErrClosed = errors.New("pool: closed")
+
+// Task is a unit of work submitted to a Pool. It receives the Worker
+// executing it, for access to that worker's scratch buffer.
+type Task func(w *Worker)
+
+// Worker is the per-goroutine state a Pool hands to every Task it runs.
+// Exactly one goroutine ever touches a given Worker at a time (its own),
+// so nothing on it needs further synchronization.
+type Worker struct {
+	id      int
+	scratch sync.Pool
+}
+
+// ID returns this worker's index in [0, NumWorkers()).
+func (w *Worker) ID() int { return w.id }
+
+// GetScratch returns a reusable buffer from this worker's own
+// sync.Pool, avoiding a fresh allocation for tasks that need scratch
+// space. Callers must return it with PutScratch when done.
+func (w *Worker) GetScratch() []byte {
+	return w.scratch.Get().([]byte)
+}
+
+// PutScratch returns a buffer obtained from GetScratch to this worker's
+// pool for reuse.
+func (w *Worker) PutScratch(b []byte) {
+	w.scratch.Put(b[:0]) //nolint:staticcheck // intentionally reset length, keep capacity
+}
+
+// Pool is a fixed-size set of long-lived worker goroutines, each
+// draining its own task queue and, when work stealing is enabled,
+// borrowing from peers' queues rather than sitting idle.
+type Pool struct {
+	workers  []*Worker
+	queues   []chan Task
+	next     uint64
+	stealing bool
+	onPanic  func(workerID int, recovered interface{})
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Option configures a Pool at construction time.
+type Option func(*config)
+
+type config struct {
+	workers    int
+	queueSize  int
+	stealing   bool
+	newScratch func() interface{}
+	onPanic    func(workerID int, recovered interface{})
+}
+
+// WithWorkers sets the number of worker goroutines. Defaults to
+// runtime.NumCPU().
+func WithWorkers(n int) Option {
+	return func(c *config) { c.workers = n }
+}
+
+// WithQueueSize sets the capacity of each worker's task queue. Defaults
+// to 256.
+func WithQueueSize(n int) Option {
+	return func(c *config) { c.queueSize = n }
+}
+
+// WithWorkStealing enables idle workers borrowing tasks from peers'
+// queues instead of blocking. Off by default: affinity-sensitive callers
+// (e.g. a Task that accumulates into per-worker state across many
+// SubmitToWorker calls) should leave it off, since a stolen task still
+// only ever sees the executing worker's own Worker, never a shared one.
+func WithWorkStealing(enabled bool) Option {
+	return func(c *config) { c.stealing = enabled }
+}
+
+// WithScratchFactory sets the allocator used to fill each worker's empty
+// sync.Pool. Defaults to a 4KB []byte.
+func WithScratchFactory(f func() interface{}) Option {
+	return func(c *config) { c.newScratch = f }
+}
+
+// WithPanicHandler sets a callback invoked (instead of crashing the
+// worker) when a Task panics. Defaults to discarding the panic.
+func WithPanicHandler(f func(workerID int, recovered interface{})) Option {
+	return func(c *config) { c.onPanic = f }
+}
+
+// New constructs and starts a Pool. Callers must call Close when done to
+// release its worker goroutines.
+func New(opts ...Option) *Pool {
+	cfg := config{
+		workers:   runtime.NumCPU(),
+		queueSize: 256,
+		newScratch: func() interface{} {
+			return make([]byte, 0, 4096)
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.workers < 1 {
+		cfg.workers = 1
+	}
+
+	p := &Pool{
+		workers:  make([]*Worker, cfg.workers),
+		queues:   make([]chan Task, cfg.workers),
+		stealing: cfg.stealing,
+		onPanic:  cfg.onPanic,
+	}
+	for i := range p.workers {
+		w := &Worker{id: i}
+		w.scratch.New = cfg.newScratch
+		p.workers[i] = w
+		p.queues[i] = make(chan Task, cfg.queueSize)
+	}
+
+	p.wg.Add(cfg.workers)
+	for i := range p.workers {
+		go p.run(i)
+	}
+	return p
+}
+
+// NumWorkers returns the number of worker goroutines in p.
+func (p *Pool) NumWorkers() int {
+	return len(p.workers)
+}
+
+// Submit queues task for the next worker in round-robin order. It
+// returns ErrClosed if the Pool has already been closed.
+func (p *Pool) Submit(task Task) error {
+	idx := int(atomic.AddUint64(&p.next, 1) % uint64(len(p.queues)))
+	return p.SubmitToWorker(idx, task)
+}
+
+// SubmitToWorker queues task for a specific worker. With work stealing
+// disabled, this guarantees task runs on that worker's own goroutine —
+// useful when task accesses state that only that worker owns.
+func (p *Pool) SubmitToWorker(workerIdx int, task Task) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+	p.queues[workerIdx%len(p.queues)] <- task
+	return nil
+}
+
+// Close stops accepting new tasks, lets every worker drain the tasks
+// already queued, and waits for all workers to exit.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	for _, q := range p.queues {
+		close(q)
+	}
+	p.wg.Wait()
+}
+
+// run is a single worker's main loop: prefer its own queue, optionally
+// steal from peers when idle, and exit once its queue is closed and
+// drained.
+func (p *Pool) run(idx int) {
+	defer p.wg.Done()
+	w := p.workers[idx]
+	own := p.queues[idx]
+
+	for {
+		select {
+		case task, ok := <-own:
+			if !ok {
+				return
+			}
+			p.execute(w, task)
+			continue
+		default:
+		}
+
+		if p.stealing {
+			if task, ok := p.steal(idx); ok {
+				p.execute(w, task)
+				continue
+			}
+			// Nothing to steal right now, but don't block on own
+			// indefinitely: a peer's queue can fill up after this
+			// attempt (e.g. tasks pinned to it via SubmitToWorker), so
+			// keep retrying steal on a short interval instead of
+			// committing to a single blocking receive.
+			select {
+			case task, ok := <-own:
+				if !ok {
+					return
+				}
+				p.execute(w, task)
+			case <-time.After(stealRetryInterval):
+			}
+			continue
+		}
+
+		task, ok := <-own
+		if !ok {
+			return
+		}
+		p.execute(w, task)
+	}
+}
+
+// steal makes one non-blocking pass over every queue but idx, taking the
+// first task it finds.
+func (p *Pool) steal(idx int) (Task, bool) {
+	for i, q := range p.queues {
+		if i == idx {
+			continue
+		}
+		select {
+		case task, ok := <-q:
+			if ok {
+				return task, true
+			}
+		default:
+		}
+	}
+	return nil, false
+}
+
+// execute runs task on w, recovering any panic so a single bad task
+// can't kill the worker goroutine.
+func (p *Pool) execute(w *Worker, task Task) {
+	defer func() {
+		if r := recover(); r != nil && p.onPanic != nil {
+			p.onPanic(w.id, r)
+		}
+	}()
+	task(w)
+}
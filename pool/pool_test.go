@@ -0,0 +1,53 @@
+package pool
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWorkStealingRetriesUnderImbalance pins all work to worker 0 via
+// SubmitToWorker, the way a Task that accumulates into per-worker state
+// normally wouldn't, and checks that stealing still spreads it across
+// every worker instead of the idle workers giving up after one failed
+// steal attempt and blocking forever on their own empty queue.
+func TestWorkStealingRetriesUnderImbalance(t *testing.T) {
+	const workers = 4
+	const tasks = 4000
+
+	p := New(WithWorkers(workers), WithWorkStealing(true))
+	defer p.Close()
+
+	var executed [workers]int64
+	done := make(chan struct{})
+	var remaining int64 = tasks
+
+	// Let the idle workers make their first steal attempt against empty
+	// queues before any work arrives, and make each task block briefly so
+	// the pinned load outlasts stealRetryInterval even on a single CPU.
+	time.Sleep(10 * time.Millisecond)
+
+	for i := 0; i < tasks; i++ {
+		if err := p.SubmitToWorker(0, func(w *Worker) {
+			atomic.AddInt64(&executed[w.ID()], 1)
+			time.Sleep(50 * time.Microsecond)
+			if atomic.AddInt64(&remaining, -1) == 0 {
+				close(done)
+			}
+		}); err != nil {
+			t.Fatalf("SubmitToWorker: %v", err)
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for all tasks; remaining=%d, executed=%v", atomic.LoadInt64(&remaining), executed)
+	}
+
+	for i, n := range executed {
+		if n == 0 {
+			t.Errorf("worker %d executed 0 tasks, want at least 1 (stealing should have spread the pinned load)", i)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package regex
+
+// inst is one instruction in a compiled NFA program, following the
+// classic Thompson-construction bytecode shape (Cox, "Regular Expression
+// Matching Can Be Simple And Fast"): opRune/opMatch are the only
+// instructions that consume input or terminate a match; opSplit and
+// opAssert are epsilon transitions followed during closure.
+type inst struct {
+	op     instOp
+	lo, hi rune       // opRune: matches any rune in [lo, hi]
+	x, y   int        // opRune/opAssert: x is next pc. opSplit: x and y are both next pcs.
+	assert assertKind // opAssert only
+}
+
+type instOp int
+
+const (
+	opRune instOp = iota
+	opSplit
+	opAssert
+	opMatch
+)
+
+// prog is a compiled NFA: a flat instruction slice plus the entry pc.
+type prog struct {
+	insts []inst
+	start int
+}
+
+// patchTarget names a not-yet-wired successor field of an instruction
+// already emitted into the compiler's instruction slice. Fragments are
+// compiled bottom-up Thompson-style before their successor's pc is
+// known, so compile returns a list of these to be filled in once it is.
+// Targets are recorded as (pc, field) rather than *int: the instruction
+// slice keeps growing as compilation proceeds, and append can reallocate
+// its backing array, which would silently strand a raw pointer into the
+// old one.
+type patchTarget struct {
+	pc    int
+	field byte // 'x' or 'y'
+}
+
+type patchList []patchTarget
+
+type compiler struct{ insts []inst }
+
+func (c *compiler) emit(i inst) int {
+	c.insts = append(c.insts, i)
+	return len(c.insts) - 1
+}
+
+func (c *compiler) patch(pl patchList, target int) {
+	for _, pt := range pl {
+		if pt.field == 'x' {
+			c.insts[pt.pc].x = target
+		} else {
+			c.insts[pt.pc].y = target
+		}
+	}
+}
+
+// compileProg runs Thompson construction over n and appends a trailing
+// opMatch, producing a runnable prog.
+func compileProg(n node) *prog {
+	c := &compiler{}
+	start, out := c.compile(n)
+	matchPC := c.emit(inst{op: opMatch})
+	c.patch(out, matchPC)
+	return &prog{insts: c.insts, start: start}
+}
+
+func (c *compiler) compile(n node) (start int, out patchList) {
+	switch v := n.(type) {
+	case litNode:
+		pc := c.emit(inst{op: opRune, lo: v.r, hi: v.r})
+		return pc, patchList{{pc, 'x'}}
+
+	case anyCharNode:
+		return c.compileClass(classNode{ranges: []runeRange{{0, '\n' - 1}, {'\n' + 1, maxRune}}})
+
+	case classNode:
+		return c.compileClass(v)
+
+	case concatNode:
+		if len(v.subs) == 0 {
+			// Empty concatenation: a no-op split that both branches share,
+			// so it behaves as "match nothing, consume nothing".
+			pc := c.emit(inst{op: opSplit})
+			return pc, patchList{{pc, 'x'}, {pc, 'y'}}
+		}
+		start, out = c.compile(v.subs[0])
+		for _, sub := range v.subs[1:] {
+			s, o := c.compile(sub)
+			c.patch(out, s)
+			out = o
+		}
+		return start, out
+
+	case altNode:
+		var allOut patchList
+		var starts []int
+		for _, sub := range v.subs {
+			s, o := c.compile(sub)
+			starts = append(starts, s)
+			allOut = append(allOut, o...)
+		}
+		// Chain splits pairwise so an N-way alternation only costs N-1
+		// split instructions.
+		pc := starts[len(starts)-1]
+		for i := len(starts) - 2; i >= 0; i-- {
+			split := c.emit(inst{op: opSplit, x: starts[i], y: pc})
+			pc = split
+		}
+		return pc, allOut
+
+	case starNode:
+		split := c.emit(inst{op: opSplit})
+		s, o := c.compile(v.sub)
+		c.insts[split].x = s
+		c.patch(o, split)
+		return split, patchList{{split, 'y'}}
+
+	case plusNode:
+		s, o := c.compile(v.sub)
+		split := c.emit(inst{op: opSplit, x: s})
+		c.patch(o, split)
+		return s, patchList{{split, 'y'}}
+
+	case questNode:
+		split := c.emit(inst{op: opSplit})
+		s, o := c.compile(v.sub)
+		c.insts[split].x = s
+		return split, append(patchList{{split, 'y'}}, o...)
+
+	case repeatNode:
+		return c.compileRepeat(v)
+
+	case assertNode:
+		pc := c.emit(inst{op: opAssert, assert: v.kind})
+		return pc, patchList{{pc, 'x'}}
+
+	default:
+		panic("regex: unknown AST node")
+	}
+}
+
+// compileRepeat expands {min,max} into min required copies followed by
+// either (max-min) optional copies or, when max is unbounded, a trailing
+// star.
+func (c *compiler) compileRepeat(v repeatNode) (int, patchList) {
+	if v.min == 0 && v.max == 0 {
+		return c.compile(concatNode{})
+	}
+	var start int
+	var out patchList
+	have := false
+	for i := 0; i < v.min; i++ {
+		s, o := c.compile(v.sub)
+		if !have {
+			start, have = s, true
+		} else {
+			c.patch(out, s)
+		}
+		out = o
+	}
+	switch {
+	case v.max == -1:
+		s, o := c.compile(starNode{sub: v.sub})
+		if !have {
+			start, have = s, true
+		} else {
+			c.patch(out, s)
+		}
+		out = o
+	case v.max > v.min:
+		for i := v.min; i < v.max; i++ {
+			s, o := c.compile(questNode{sub: v.sub})
+			if !have {
+				start, have = s, true
+			} else {
+				c.patch(out, s)
+			}
+			out = o
+		}
+	}
+	return start, out
+}
+
+// compileClass compiles a (possibly negated) set of rune ranges into a
+// chain of opRune alternatives, same shape as compiling an altNode of
+// single-rune literals.
+func (c *compiler) compileClass(v classNode) (int, patchList) {
+	ranges := v.ranges
+	if v.negate {
+		ranges = negateRanges(mergeRanges(v.ranges))
+	}
+	if len(ranges) == 0 {
+		// Matches nothing: an opRune with an empty range can never match.
+		pc := c.emit(inst{op: opRune, lo: 1, hi: 0})
+		return pc, patchList{{pc, 'x'}}
+	}
+	if len(ranges) == 1 {
+		pc := c.emit(inst{op: opRune, lo: ranges[0].lo, hi: ranges[0].hi})
+		return pc, patchList{{pc, 'x'}}
+	}
+	// More than one range: same split-chain shape as an alternation of
+	// single-range literals, so a [a-zA-Z0-9_] class costs len(ranges)-1
+	// splits rather than a linear scan over ranges at match time.
+	starts := make([]int, len(ranges))
+	var allOut patchList
+	for i, r := range ranges {
+		pc := c.emit(inst{op: opRune, lo: r.lo, hi: r.hi})
+		starts[i] = pc
+		allOut = append(allOut, patchTarget{pc, 'x'})
+	}
+	pc := starts[len(starts)-1]
+	for i := len(starts) - 2; i >= 0; i-- {
+		split := c.emit(inst{op: opSplit, x: starts[i], y: pc})
+		pc = split
+	}
+	return pc, allOut
+}
@@ -0,0 +1,244 @@
+package regex
+
+import (
+	"container/list"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// emptyFlags records which zero-width assertions hold at a single
+// position in the input (the point between a "previous" rune, if any,
+// and the next rune to be consumed, if any). It's computed once per
+// position from just that pair of runes, following the same scheme Go's
+// own regexp/syntax package uses for EmptyOp flags.
+type emptyFlags uint8
+
+const (
+	flagBeginText emptyFlags = 1 << iota
+	flagEndText
+	flagWordBoundary
+	flagNotWordBoundary
+)
+
+func isWordRune(r rune) bool {
+	return r == '_' || (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z')
+}
+
+func computeFlags(havePrev bool, prev rune, haveCur bool, cur rune) emptyFlags {
+	var f emptyFlags
+	if !havePrev {
+		f |= flagBeginText
+	}
+	if !haveCur {
+		f |= flagEndText
+	}
+	if (havePrev && isWordRune(prev)) != (haveCur && isWordRune(cur)) {
+		f |= flagWordBoundary
+	} else {
+		f |= flagNotWordBoundary
+	}
+	return f
+}
+
+func (f emptyFlags) satisfies(kind assertKind) bool {
+	switch kind {
+	case assertBeginText:
+		return f&flagBeginText != 0
+	case assertEndText:
+		return f&flagEndText != 0
+	case assertWordBoundary:
+		return f&flagWordBoundary != 0
+	case assertNotWordBoundary:
+		return f&flagNotWordBoundary != 0
+	default:
+		return false
+	}
+}
+
+// closure computes the epsilon-closure of starts under flags: it follows
+// every opSplit and every opAssert whose condition flags satisfies,
+// stopping at opRune instructions (the "frontier", sorted and
+// deduplicated) and noting whether opMatch was reachable.
+func closure(p *prog, starts []int, flags emptyFlags) (frontier []int, isMatch bool) {
+	visited := make([]bool, len(p.insts))
+	var visit func(pc int)
+	visit = func(pc int) {
+		if visited[pc] {
+			return
+		}
+		visited[pc] = true
+		in := p.insts[pc]
+		switch in.op {
+		case opRune:
+			frontier = append(frontier, pc)
+		case opMatch:
+			isMatch = true
+		case opSplit:
+			visit(in.x)
+			visit(in.y)
+		case opAssert:
+			if flags.satisfies(in.assert) {
+				visit(in.x)
+			}
+		}
+	}
+	for _, pc := range starts {
+		visit(pc)
+	}
+	sort.Ints(frontier)
+	return frontier, isMatch
+}
+
+// dfaState is one node of the lazily-built DFA: the (deduplicated,
+// sorted) set of NFA frontier pcs reachable here, plus memoized outgoing
+// transitions so repeated scans over similar text don't redo closure
+// work. trans holds ordinary (single-origin) transitions used by an
+// anchored-at-one-position scan; transUnanchored holds transitions that
+// additionally re-seed the program's start state every step, which is
+// what lets Match scan for an occurrence anywhere in one linear pass
+// instead of restarting the search at every byte.
+type dfaState struct {
+	frontier []int
+	isMatch  bool
+
+	trans           map[int64]*dfaState
+	transUnanchored map[int64]*dfaState
+}
+
+func newDFAState(frontier []int, isMatch bool) *dfaState {
+	return &dfaState{
+		frontier:        frontier,
+		isMatch:         isMatch,
+		trans:           make(map[int64]*dfaState),
+		transUnanchored: make(map[int64]*dfaState),
+	}
+}
+
+type cacheEntry struct {
+	key   string
+	state *dfaState
+}
+
+// dfaCache builds DFA states from a prog on demand and caches them by
+// their frontier signature, evicting the least-recently-used entry once
+// it holds more than capacity states. This bounds how many states the
+// cache indexes; an evicted state is not freed while another state's
+// trans or transUnanchored map still points to it, so total memory is
+// not bounded by capacity.
+type dfaCache struct {
+	mu       sync.Mutex
+	prog     *prog
+	capacity int
+	byKey    map[string]*list.Element
+	lru      *list.List // front = most recently used
+}
+
+func newDFACache(p *prog, capacity int) *dfaCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &dfaCache{prog: p, capacity: capacity, byKey: make(map[string]*list.Element), lru: list.New()}
+}
+
+// frontierKey builds the cache key for a (frontier, isMatch) pair. Two
+// closures can legitimately produce the same frontier pc-set with
+// different isMatch (e.g. for `a+`, the unconsumed start state and the
+// state reached after consuming one `a` both close to the same opRune
+// frontier, but only the second is a match), so isMatch must be part of
+// the key — a frontier-only key would collapse them into one dfaState
+// and freeze whichever isMatch was inserted first.
+func frontierKey(frontier []int, isMatch bool) string {
+	var sb strings.Builder
+	if isMatch {
+		sb.WriteByte('m')
+	} else {
+		sb.WriteByte('-')
+	}
+	for _, pc := range frontier {
+		sb.WriteByte(',')
+		sb.WriteString(strconv.Itoa(pc))
+	}
+	return sb.String()
+}
+
+// state returns the cached dfaState for (frontier, isMatch), building
+// and registering one if this is the first time it's been seen. Must be
+// called with c.mu held.
+func (c *dfaCache) state(frontier []int, isMatch bool) *dfaState {
+	key := frontierKey(frontier, isMatch)
+	if el, ok := c.byKey[key]; ok {
+		c.lru.MoveToFront(el)
+		return el.Value.(*cacheEntry).state
+	}
+	st := newDFAState(frontier, isMatch)
+	el := c.lru.PushFront(&cacheEntry{key: key, state: st})
+	c.byKey[key] = el
+	if c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.byKey, oldest.Value.(*cacheEntry).key)
+	}
+	return st
+}
+
+// start returns the DFA's entry state for a scan beginning under flags.
+func (c *dfaCache) start(flags emptyFlags) *dfaState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	frontier, isMatch := closure(c.prog, []int{c.prog.start}, flags)
+	return c.state(frontier, isMatch)
+}
+
+// step advances st by consuming rune r under flags (the assertion
+// context immediately after r). It returns nil once no rune in st's
+// frontier can match r, signaling that this single-origin match attempt
+// is dead and should stop extending.
+func (c *dfaCache) step(st *dfaState, r rune, flags emptyFlags) *dfaState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tkey := int64(r)<<8 | int64(flags)
+	if next, ok := st.trans[tkey]; ok {
+		return next
+	}
+	raw := matchingTargets(c.prog, st.frontier, r)
+	var next *dfaState
+	if len(raw) > 0 {
+		frontier, isMatch := closure(c.prog, raw, flags)
+		next = c.state(frontier, isMatch)
+	}
+	st.trans[tkey] = next
+	return next
+}
+
+// stepUnanchored is like step but also re-seeds the program's start
+// state into the raw successor set before closure, so the returned
+// state represents "still extending an earlier match attempt, or just
+// starting a fresh one here" simultaneously. A single scan using this
+// never needs to restart from scratch, which is what gives Match its
+// linear-time guarantee against pathological patterns.
+func (c *dfaCache) stepUnanchored(st *dfaState, r rune, flags emptyFlags) *dfaState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tkey := int64(r)<<8 | int64(flags)
+	if next, ok := st.transUnanchored[tkey]; ok {
+		return next
+	}
+	raw := append(matchingTargets(c.prog, st.frontier, r), c.prog.start)
+	frontier, isMatch := closure(c.prog, raw, flags)
+	next := c.state(frontier, isMatch)
+	st.transUnanchored[tkey] = next
+	return next
+}
+
+func matchingTargets(p *prog, frontier []int, r rune) []int {
+	var raw []int
+	for _, pc := range frontier {
+		in := p.insts[pc]
+		if r >= in.lo && r <= in.hi {
+			raw = append(raw, in.x)
+		}
+	}
+	return raw
+}
@@ -0,0 +1,405 @@
+package regex
+
+import (
+	"fmt"
+	"sort"
+)
+
+// node is a regex AST node. The concrete types below are the only
+// implementations.
+type node interface{}
+
+type litNode struct{ r rune }
+
+type anyCharNode struct{}
+
+type runeRange struct{ lo, hi rune }
+
+type classNode struct {
+	ranges []runeRange
+	negate bool
+}
+
+type concatNode struct{ subs []node }
+
+type altNode struct{ subs []node }
+
+type starNode struct{ sub node }
+
+type plusNode struct{ sub node }
+
+type questNode struct{ sub node }
+
+// repeatNode is {min,max}; max == -1 means unbounded.
+type repeatNode struct {
+	sub      node
+	min, max int
+}
+
+type assertKind int
+
+const (
+	assertBeginText assertKind = iota
+	assertEndText
+	assertWordBoundary
+	assertNotWordBoundary
+)
+
+type assertNode struct{ kind assertKind }
+
+// digitRanges, wordRanges and spaceRanges back the \d, \w and \s
+// shorthand classes (and their negations \D, \W, \S).
+var (
+	digitRanges = []runeRange{{'0', '9'}}
+	wordRanges  = []runeRange{{'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'}}
+	spaceRanges = []runeRange{{'\t', '\n'}, {'\f', '\r'}, {' ', ' '}}
+)
+
+// maxRune bounds the rune ranges this package reasons about; it's
+// unicode.MaxRune, duplicated here to avoid importing unicode for one
+// constant.
+const maxRune = 0x10FFFF
+
+type parser struct {
+	src []rune
+	pos int
+}
+
+// parse compiles pattern's syntax tree. It supports literals, `.`,
+// character classes (`[a-z]`, negation, \d \w \s shorthands), the \d \w
+// \s \D \W \S shorthands outside classes, \b \B word-boundary
+// assertions, ^ and $ anchors, grouping, alternation `|`, and the `*` `+`
+// `?` `{m,n}` quantifiers.
+func parse(pattern string) (node, error) {
+	p := &parser{src: []rune(pattern)}
+	n, err := p.parseAlt()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.src) {
+		return nil, fmt.Errorf("regex: unexpected %q at offset %d", p.peekRune(), p.pos)
+	}
+	return n, nil
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *parser) peekRune() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) parseAlt() (node, error) {
+	first, err := p.parseConcat()
+	if err != nil {
+		return nil, err
+	}
+	subs := []node{first}
+	for !p.eof() && p.peekRune() == '|' {
+		p.pos++
+		n, err := p.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, n)
+	}
+	if len(subs) == 1 {
+		return subs[0], nil
+	}
+	return altNode{subs: subs}, nil
+}
+
+func (p *parser) parseConcat() (node, error) {
+	var subs []node
+	for !p.eof() && p.peekRune() != '|' && p.peekRune() != ')' {
+		n, err := p.parseRepeat()
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, n)
+	}
+	if len(subs) == 0 {
+		return concatNode{}, nil
+	}
+	if len(subs) == 1 {
+		return subs[0], nil
+	}
+	return concatNode{subs: subs}, nil
+}
+
+func (p *parser) parseRepeat() (node, error) {
+	atom, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	for !p.eof() {
+		switch p.peekRune() {
+		case '*':
+			p.pos++
+			atom = starNode{sub: atom}
+			continue
+		case '+':
+			p.pos++
+			atom = plusNode{sub: atom}
+			continue
+		case '?':
+			p.pos++
+			atom = questNode{sub: atom}
+			continue
+		case '{':
+			n, ok, err := p.tryParseBound(atom)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return atom, nil
+			}
+			atom = n
+			continue
+		}
+		break
+	}
+	return atom, nil
+}
+
+// tryParseBound parses a {m,n} bound at the current position. If what
+// follows '{' isn't a valid bound, it leaves p.pos unchanged and returns
+// ok=false so the '{' is treated as a literal by the caller.
+func (p *parser) tryParseBound(atom node) (node, bool, error) {
+	start := p.pos
+	p.pos++ // consume '{'
+	min, ok := p.parseInt()
+	if !ok {
+		p.pos = start
+		return nil, false, nil
+	}
+	max := min
+	if !p.eof() && p.peekRune() == ',' {
+		p.pos++
+		if !p.eof() && p.peekRune() == '}' {
+			max = -1
+		} else {
+			n, ok := p.parseInt()
+			if !ok {
+				p.pos = start
+				return nil, false, nil
+			}
+			max = n
+		}
+	}
+	if p.eof() || p.peekRune() != '}' {
+		p.pos = start
+		return nil, false, nil
+	}
+	p.pos++ // consume '}'
+	if max != -1 && max < min {
+		return nil, false, fmt.Errorf("regex: invalid repeat count {%d,%d}", min, max)
+	}
+	return repeatNode{sub: atom, min: min, max: max}, true, nil
+}
+
+func (p *parser) parseInt() (int, bool) {
+	start := p.pos
+	for !p.eof() && p.peekRune() >= '0' && p.peekRune() <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, false
+	}
+	n := 0
+	for _, r := range p.src[start:p.pos] {
+		n = n*10 + int(r-'0')
+	}
+	return n, true
+}
+
+func (p *parser) parseAtom() (node, error) {
+	r := p.peekRune()
+	switch r {
+	case '(':
+		p.pos++
+		n, err := p.parseAlt()
+		if err != nil {
+			return nil, err
+		}
+		if p.eof() || p.peekRune() != ')' {
+			return nil, fmt.Errorf("regex: missing closing ')'")
+		}
+		p.pos++
+		return n, nil
+	case '.':
+		p.pos++
+		return anyCharNode{}, nil
+	case '[':
+		return p.parseClass()
+	case '^':
+		p.pos++
+		return assertNode{kind: assertBeginText}, nil
+	case '$':
+		p.pos++
+		return assertNode{kind: assertEndText}, nil
+	case '\\':
+		return p.parseEscape()
+	default:
+		p.pos++
+		return litNode{r: r}, nil
+	}
+}
+
+func (p *parser) parseEscape() (node, error) {
+	p.pos++ // consume '\'
+	if p.eof() {
+		return nil, fmt.Errorf("regex: trailing '\\'")
+	}
+	r := p.src[p.pos]
+	p.pos++
+	switch r {
+	case 'd':
+		return classNode{ranges: digitRanges}, nil
+	case 'D':
+		return classNode{ranges: digitRanges, negate: true}, nil
+	case 'w':
+		return classNode{ranges: wordRanges}, nil
+	case 'W':
+		return classNode{ranges: wordRanges, negate: true}, nil
+	case 's':
+		return classNode{ranges: spaceRanges}, nil
+	case 'S':
+		return classNode{ranges: spaceRanges, negate: true}, nil
+	case 'b':
+		return assertNode{kind: assertWordBoundary}, nil
+	case 'B':
+		return assertNode{kind: assertNotWordBoundary}, nil
+	case 'n':
+		return litNode{r: '\n'}, nil
+	case 't':
+		return litNode{r: '\t'}, nil
+	case 'r':
+		return litNode{r: '\r'}, nil
+	default:
+		return litNode{r: r}, nil
+	}
+}
+
+func (p *parser) parseClass() (node, error) {
+	p.pos++ // consume '['
+	negate := false
+	if !p.eof() && p.peekRune() == '^' {
+		negate = true
+		p.pos++
+	}
+	var ranges []runeRange
+	first := true
+	for {
+		if p.eof() {
+			return nil, fmt.Errorf("regex: missing closing ']'")
+		}
+		if p.peekRune() == ']' && !first {
+			p.pos++
+			break
+		}
+		first = false
+		lo, err := p.parseClassRune(&ranges)
+		if err != nil {
+			return nil, err
+		}
+		if lo == nil {
+			continue // a shorthand class was appended directly
+		}
+		hi := *lo
+		if !p.eof() && p.peekRune() == '-' && p.pos+1 < len(p.src) && p.src[p.pos+1] != ']' {
+			p.pos++ // consume '-'
+			h, err := p.parseClassRune(&ranges)
+			if err != nil {
+				return nil, err
+			}
+			if h == nil {
+				return nil, fmt.Errorf("regex: invalid range end in class")
+			}
+			hi = *h
+		}
+		ranges = append(ranges, runeRange{lo: *lo, hi: hi})
+	}
+	return classNode{ranges: mergeRanges(ranges), negate: negate}, nil
+}
+
+// parseClassRune parses one rune-or-shorthand inside [...]. Literal runes
+// are returned via the pointer; \d \w \s shorthands instead append
+// directly to *ranges and return nil.
+func (p *parser) parseClassRune(ranges *[]runeRange) (*rune, error) {
+	r := p.src[p.pos]
+	if r != '\\' {
+		p.pos++
+		return &r, nil
+	}
+	p.pos++
+	if p.eof() {
+		return nil, fmt.Errorf("regex: trailing '\\' in class")
+	}
+	esc := p.src[p.pos]
+	p.pos++
+	switch esc {
+	case 'd':
+		*ranges = append(*ranges, digitRanges...)
+		return nil, nil
+	case 'w':
+		*ranges = append(*ranges, wordRanges...)
+		return nil, nil
+	case 's':
+		*ranges = append(*ranges, spaceRanges...)
+		return nil, nil
+	case 'n':
+		r := '\n'
+		return &r, nil
+	case 't':
+		r := '\t'
+		return &r, nil
+	case 'r':
+		r := '\r'
+		return &r, nil
+	default:
+		return &esc, nil
+	}
+}
+
+// mergeRanges sorts ranges and merges any that overlap or touch.
+func mergeRanges(ranges []runeRange) []runeRange {
+	if len(ranges) == 0 {
+		return ranges
+	}
+	sorted := append([]runeRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].lo < sorted[j].lo })
+	merged := sorted[:1]
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if r.lo <= last.hi+1 {
+			if r.hi > last.hi {
+				last.hi = r.hi
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// negateRanges returns the complement of ranges (assumed merged and
+// sorted) within [0, maxRune].
+func negateRanges(ranges []runeRange) []runeRange {
+	var out []runeRange
+	next := rune(0)
+	for _, r := range ranges {
+		if r.lo > next {
+			out = append(out, runeRange{lo: next, hi: r.lo - 1})
+		}
+		if r.hi+1 > next {
+			next = r.hi + 1
+		}
+	}
+	if next <= maxRune {
+		out = append(out, runeRange{lo: next, hi: maxRune})
+	}
+	return out
+}
@@ -0,0 +1,256 @@
+// Package regex is a from-scratch regular expression engine built the
+// way RE2 and Go's own regexp package are: Thompson construction compiles
+// a pattern to an NFA, and matching lazily performs subset construction
+// over it into a DFA, caching states as they're discovered rather than
+// building the (potentially exponential) full DFA up front. Because
+// matching only ever walks forward through a bounded number of DFA
+// states — never backtracking through alternatives — a pattern like
+// `(a|a)*b` runs in time linear in the input even on adversarial input
+// that makes backtracking engines take exponential time.
+//
+// The lazy DFA's state cache is bounded (see defaultCacheSize) and
+// evicts least-recently-used states once full. An evicted state stays in
+// memory for as long as another state's memoized transitions still point
+// to it, so the bound caps the cache's index, not the total number of
+// states alive.
+//
+// Patterns that are a pure alternation of at least literalAltThreshold
+// literal strings (no other operators) are instead compiled to an
+// Aho-Corasick trie, the right
+// algorithm for "match any of these words" and the natural fast path for
+// the large-alternation case the DFA route would otherwise spend one
+// opSplit per branch on.
+//
+// This engine trades away some stdlib regexp features for that
+// guarantee and for a much smaller implementation: it matches over
+// decoded runes rather than raw UTF-8 bytes, and it has no capturing
+// groups or backreferences.
+package regex
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// defaultCacheSize bounds the number of lazily-built DFA states kept
+// alive per Regexp.
+const defaultCacheSize = 4096
+
+// literalAltThreshold is the minimum number of pure-literal alternatives
+// a pattern needs before Compile routes it to the Aho-Corasick trie
+// instead of the general NFA/DFA engine. Below this, small alternations
+// like `(a|a)` stay on the DFA path, since exercising that path against
+// small-but-redundant alternations is exactly what demonstrates its
+// resistance to the blowup a backtracking engine would suffer there.
+const literalAltThreshold = 8
+
+// Regexp is a compiled pattern. A Regexp is safe for concurrent use by
+// multiple goroutines: its DFA state cache is mutex-guarded.
+type Regexp struct {
+	src   string
+	prog  *prog
+	cache *dfaCache
+	trie  *acAutomaton // non-nil for large pure-literal alternations
+}
+
+// Compile parses and compiles pattern. See the package doc for the
+// supported syntax and its differences from stdlib regexp.
+func Compile(pattern string) (*Regexp, error) {
+	ast, err := parse(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("regex: %w", err)
+	}
+
+	re := &Regexp{src: pattern}
+	if words, ok := literalAlternatives(ast); ok && len(words) >= literalAltThreshold {
+		re.trie = buildAhoCorasick(words)
+		return re, nil
+	}
+
+	re.prog = compileProg(ast)
+	re.cache = newDFACache(re.prog, defaultCacheSize)
+	return re, nil
+}
+
+// String returns the source pattern re was compiled from.
+func (re *Regexp) String() string { return re.src }
+
+// literalAlternatives reports whether n is an alternation of subpatterns
+// that are each themselves nothing but a literal string, returning those
+// strings if so.
+func literalAlternatives(n node) ([]string, bool) {
+	alt, ok := n.(altNode)
+	if !ok {
+		return nil, false
+	}
+	words := make([]string, len(alt.subs))
+	for i, sub := range alt.subs {
+		w, ok := literalOf(sub)
+		if !ok {
+			return nil, false
+		}
+		words[i] = w
+	}
+	return words, true
+}
+
+// literalOf reports whether n is built entirely from literal runes
+// (directly, or concatenated), returning the resulting string if so.
+func literalOf(n node) (string, bool) {
+	switch v := n.(type) {
+	case litNode:
+		return string(v.r), true
+	case concatNode:
+		s := make([]rune, 0, len(v.subs))
+		for _, sub := range v.subs {
+			lit, ok := sub.(litNode)
+			if !ok {
+				return "", false
+			}
+			s = append(s, lit.r)
+		}
+		return string(s), true
+	default:
+		return "", false
+	}
+}
+
+// FindAllIndex returns the start and end byte offset of every
+// non-overlapping match in text, leftmost first. Among matches sharing a
+// start position it returns the longest one, same as POSIX leftmost-
+// longest semantics.
+func (re *Regexp) FindAllIndex(text []byte) [][]int {
+	if re.trie != nil {
+		return re.trie.findAllIndex(text)
+	}
+
+	runes, offsets := decodeRunes(text)
+	var result [][]int
+	i := 0
+	for i <= len(runes) {
+		end, ok := re.longestMatchFrom(runes, i)
+		if !ok {
+			i++
+			continue
+		}
+		result = append(result, []int{offsets[i], offsets[end]})
+		if end == i {
+			i++ // avoid looping forever on a zero-width match
+		} else {
+			i = end
+		}
+	}
+	return result
+}
+
+// FindAll returns the text of every non-overlapping match, leftmost
+// first.
+func (re *Regexp) FindAll(text []byte) [][]byte {
+	idx := re.FindAllIndex(text)
+	out := make([][]byte, len(idx))
+	for i, pair := range idx {
+		out[i] = text[pair[0]:pair[1]]
+	}
+	return out
+}
+
+// longestMatchFrom runs the lazy DFA from position start and returns the
+// furthest position at which it was in a matching state, if any.
+func (re *Regexp) longestMatchFrom(runes []rune, start int) (int, bool) {
+	flags := computeFlags(start > 0, runeBefore(runes, start), start < len(runes), runeAt(runes, start))
+	st := re.cache.start(flags)
+	bestEnd, matched := start, st.isMatch
+
+	pos := start
+	for pos < len(runes) {
+		r := runes[pos]
+		haveNext := pos+1 < len(runes)
+		flags := computeFlags(true, r, haveNext, runeAt(runes, pos+1))
+		next := re.cache.step(st, r, flags)
+		if next == nil {
+			break
+		}
+		st = next
+		pos++
+		if st.isMatch {
+			bestEnd, matched = pos, true
+		}
+	}
+	return bestEnd, matched
+}
+
+func runeAt(runes []rune, i int) rune {
+	if i < 0 || i >= len(runes) {
+		return 0
+	}
+	return runes[i]
+}
+
+func runeBefore(runes []rune, i int) rune { return runeAt(runes, i-1) }
+
+// decodeRunes decodes text into runes alongside a parallel slice mapping
+// each rune index (plus one sentinel past the end) to its byte offset in
+// text, so matches found over runes can be reported as byte offsets like
+// stdlib regexp's.
+func decodeRunes(text []byte) (runes []rune, offsets []int) {
+	s := string(text)
+	runes = make([]rune, 0, len(s))
+	offsets = make([]int, 0, len(s)+1)
+	for i, r := range s {
+		runes = append(runes, r)
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(text))
+	return runes, offsets
+}
+
+// Match reports whether pattern re occurs anywhere in r, reading it
+// through a fixed-size bufio.Reader rather than holding it whole — useful
+// for inputs too large to hold in memory. It re-seeds the DFA's start
+// state on every rune (see dfaCache.stepUnanchored), so the whole search
+// runs in a single linear pass rather than restarting at each position,
+// even for patterns like `(a|a)*b` that would make a backtracking
+// engine's unanchored search quadratic or worse.
+func (re *Regexp) Match(r io.Reader) (bool, error) {
+	if re.trie != nil {
+		return re.trie.matchReader(r)
+	}
+
+	br := bufio.NewReader(r)
+	haveCur, cur, err := readRuneOK(br)
+	if err != nil {
+		return false, err
+	}
+
+	flags := computeFlags(false, 0, haveCur, cur)
+	st := re.cache.start(flags)
+	if st.isMatch {
+		return true, nil
+	}
+
+	for haveCur {
+		consumed := cur
+		haveCur, cur, err = readRuneOK(br)
+		if err != nil {
+			return false, err
+		}
+		flags := computeFlags(true, consumed, haveCur, cur)
+		st = re.cache.stepUnanchored(st, consumed, flags)
+		if st.isMatch {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func readRuneOK(br *bufio.Reader) (bool, rune, error) {
+	r, _, err := br.ReadRune()
+	if err == io.EOF {
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	return true, r, nil
+}
@@ -0,0 +1,84 @@
+package regex
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// agreementCases pairs a pattern with sample texts that metal0/regex
+// must match identically to stdlib regexp's FindAllString. Patterns
+// with top-level alternation between alternatives that can both match
+// at the same start position are deliberately excluded: this package
+// uses POSIX leftmost-longest tie-breaking (see Regexp.FindAllIndex's
+// doc), while stdlib regexp's default mode is leftmost-first, so those
+// two can legitimately disagree there.
+var agreementCases = []struct {
+	pattern string
+	texts   []string
+}{
+	{`[a-z]+`, []string{"hello", "Hello World", "", "abc123def"}},
+	{`\d+`, []string{"abc123def456", "42", "no digits here"}},
+	{`\w+`, []string{"hello_world 123", "  spaced  out  "}},
+	{`\s+`, []string{"a b  c\td\ne"}},
+	{`a+`, []string{"banana", "aaa", "b"}},
+	{`^abc`, []string{"abcdef", "xabcdef", "abc"}},
+	{`abc$`, []string{"xabc", "abcx", "abc"}},
+	{`a*b`, []string{"aaab", "b", "aaa"}},
+	{`\bfoo\b`, []string{"foo bar foofoo foo.", "xfoox foo"}},
+	{`[0-9]{2,4}`, []string{"1 22 333 4444 55555"}},
+	{`colou?r`, []string{"color colour colouur"}},
+	{`.+`, []string{"abc\ndef", ""}},
+}
+
+func TestFindAllAgreesWithStdlib(t *testing.T) {
+	for _, tc := range agreementCases {
+		tc := tc
+		t.Run(tc.pattern, func(t *testing.T) {
+			stdRe, err := regexp.Compile(tc.pattern)
+			if err != nil {
+				t.Fatalf("regexp.Compile(%q): %v", tc.pattern, err)
+			}
+			metalRe, err := Compile(tc.pattern)
+			if err != nil {
+				t.Fatalf("Compile(%q): %v", tc.pattern, err)
+			}
+			for _, text := range tc.texts {
+				want := stdRe.FindAllString(text, -1)
+				got := metalRe.FindAll([]byte(text))
+				gotStrs := make([]string, len(got))
+				for i, b := range got {
+					gotStrs[i] = string(b)
+				}
+				if len(gotStrs) == 0 && len(want) == 0 {
+					continue
+				}
+				if !reflect.DeepEqual(gotStrs, want) {
+					t.Errorf("pattern %q, text %q: metal0/regex=%v, stdlib=%v", tc.pattern, text, gotStrs, want)
+				}
+			}
+		})
+	}
+}
+
+// TestDFACacheDistinguishesMatchAtSameFrontier is a regression test for
+// the lazy DFA cache keying states by frontier pc-set alone: `a+`
+// closes to the same opRune frontier both before any input is consumed
+// (not a match) and after consuming one `a` (a match), and a cache that
+// ignores isMatch collapses the two, losing matches entirely.
+func TestDFACacheDistinguishesMatchAtSameFrontier(t *testing.T) {
+	re, err := Compile(`a+`)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	got := re.FindAll([]byte("banana"))
+	want := [][]byte{[]byte("a"), []byte("a"), []byte("a")}
+	if len(got) != len(want) {
+		t.Fatalf("FindAll(%q) = %v, want %v", "banana", got, want)
+	}
+	for i := range want {
+		if string(got[i]) != string(want[i]) {
+			t.Fatalf("FindAll(%q) = %v, want %v", "banana", got, want)
+		}
+	}
+}
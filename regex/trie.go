@@ -0,0 +1,141 @@
+package regex
+
+import (
+	"bufio"
+	"io"
+	"sort"
+)
+
+// acNode is one state of an Aho-Corasick automaton: a trie node plus a
+// failure link and the lengths of every literal (this node's own and any
+// inherited via fail links) that ends here.
+type acNode struct {
+	children map[rune]*acNode
+	fail     *acNode
+	outputs  []int
+}
+
+// acAutomaton matches a fixed set of literal strings in one pass over
+// the input, in time linear in len(text) regardless of how many
+// literals there are or how much they overlap. Compile routes a pattern
+// here instead of through the NFA/DFA engine when it's a large
+// alternation of pure literals (e.g. `foo|bar|baz|...`), since that
+// shape would otherwise cost one opSplit per branch for no benefit: an
+// Aho-Corasick trie is the right algorithm for "search for any of these
+// words", the same way a lazy DFA is the right algorithm for general
+// regular expressions.
+type acAutomaton struct {
+	root *acNode
+}
+
+func buildAhoCorasick(words []string) *acAutomaton {
+	root := &acNode{children: make(map[rune]*acNode)}
+	for _, w := range words {
+		n := root
+		length := 0
+		for _, r := range w {
+			length++
+			c, ok := n.children[r]
+			if !ok {
+				c = &acNode{children: make(map[rune]*acNode)}
+				n.children[r] = c
+			}
+			n = c
+		}
+		n.outputs = append(n.outputs, length)
+	}
+
+	queue := make([]*acNode, 0, len(root.children))
+	for _, c := range root.children {
+		c.fail = root
+		queue = append(queue, c)
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for r, c := range n.children {
+			queue = append(queue, c)
+			f := n.fail
+			for f != nil {
+				if fc, ok := f.children[r]; ok {
+					c.fail = fc
+					break
+				}
+				f = f.fail
+			}
+			if c.fail == nil {
+				c.fail = root
+			}
+			c.outputs = append(c.outputs, c.fail.outputs...)
+		}
+	}
+	return &acAutomaton{root: root}
+}
+
+// findAllIndex returns every non-overlapping match, preferring the
+// leftmost start and, among matches sharing a start, the longest one —
+// the same leftmost-longest tie-break the NFA/DFA path uses.
+func (a *acAutomaton) findAllIndex(text []byte) [][]int {
+	runes, offsets := decodeRunes(text)
+	var raw [][2]int
+	n := a.root
+	for i, r := range runes {
+		for n != a.root {
+			if _, ok := n.children[r]; ok {
+				break
+			}
+			n = n.fail
+		}
+		if c, ok := n.children[r]; ok {
+			n = c
+		}
+		for _, length := range n.outputs {
+			raw = append(raw, [2]int{i - length + 1, i + 1})
+		}
+	}
+
+	sort.Slice(raw, func(i, j int) bool {
+		if raw[i][0] != raw[j][0] {
+			return raw[i][0] < raw[j][0]
+		}
+		return raw[i][1] > raw[j][1]
+	})
+	var result [][]int
+	nextAllowed := 0
+	for _, m := range raw {
+		if m[0] < nextAllowed {
+			continue
+		}
+		result = append(result, []int{offsets[m[0]], offsets[m[1]]})
+		nextAllowed = m[1]
+	}
+	return result
+}
+
+// matchReader reports whether any literal occurs anywhere in r, reading
+// one rune at a time rather than buffering the whole input.
+func (a *acAutomaton) matchReader(r io.Reader) (bool, error) {
+	br := bufio.NewReader(r)
+	n := a.root
+	for {
+		ru, _, err := br.ReadRune()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		for n != a.root {
+			if _, ok := n.children[ru]; ok {
+				break
+			}
+			n = n.fail
+		}
+		if c, ok := n.children[ru]; ok {
+			n = c
+		}
+		if len(n.outputs) > 0 {
+			return true, nil
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package contextswitch benchmarks scheduler overhead via rapid
+// yield/resume (runtime.Gosched), and reports the per-yield latency
+// distribution.
+package contextswitch
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"metal0/bench"
+	"metal0/bench/latency"
+)
+
+const numPingers = 10
+
+var (
+	lastLatencyMu sync.Mutex
+	lastLatency   *latency.Recorder
+)
+
+func ping(yields int, rec *latency.Recorder, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for i := 0; i < yields; i++ {
+		start := time.Now()
+		runtime.Gosched()
+		rec.RecordValue(time.Since(start))
+	}
+}
+
+// Benchmark_ContextSwitch spawns numPingers goroutines that each call
+// runtime.Gosched() b.N/numPingers times, recording each yield's latency
+// into its own goroutine-local latency.Recorder. The merged distribution
+// is available afterwards via LastLatency.
+func Benchmark_ContextSwitch(b *bench.B) {
+	yieldsPerPinger := b.N / numPingers
+	if yieldsPerPinger < 1 {
+		yieldsPerPinger = 1
+	}
+
+	recorders := make([]*latency.Recorder, numPingers)
+	var wg sync.WaitGroup
+	wg.Add(numPingers)
+	for i := 0; i < numPingers; i++ {
+		rec := latency.NewRecorder()
+		recorders[i] = rec
+		go ping(yieldsPerPinger, rec, &wg)
+	}
+	wg.Wait()
+
+	lastLatencyMu.Lock()
+	lastLatency = latency.Merge(recorders...)
+	lastLatencyMu.Unlock()
+}
+
+// LastLatency returns the merged per-yield latency distribution recorded
+// by the most recently completed Benchmark_ContextSwitch run.
+func LastLatency() *latency.Recorder {
+	lastLatencyMu.Lock()
+	defer lastLatencyMu.Unlock()
+	return lastLatency
+}
@@ -0,0 +1,81 @@
+// Package fanout benchmarks a fan-out/fan-in pattern: submit many tasks,
+// each doing a small amount of CPU work, to a Pool and collect their
+// results. It also reports the resulting per-task latency distribution.
+package fanout
+
+import (
+	"sync"
+	"time"
+
+	"metal0/bench"
+	"metal0/bench/latency"
+	"metal0/pool"
+)
+
+const workPerTask = 10000
+
+func worker(taskID int) int64 {
+	var result int64
+	for i := 0; i < workPerTask; i++ {
+		result += int64(i * taskID)
+	}
+	return result
+}
+
+var (
+	lastLatencyMu sync.Mutex
+	lastLatency   *latency.Recorder
+)
+
+// Benchmark_FanOut fans b.N tasks out across a Pool and fans their
+// results back in, recording each task's latency into the executing
+// worker's own latency.Recorder (work stealing stays off, so a
+// recorder is only ever touched by the worker that owns it). The merged
+// distribution is available afterwards via LastLatency.
+func Benchmark_FanOut(b *bench.B) {
+	p := pool.New()
+	defer p.Close()
+	workers := p.NumWorkers()
+
+	recorders := make([]*latency.Recorder, workers)
+	for i := range recorders {
+		recorders[i] = latency.NewRecorder()
+	}
+
+	futures := make([]*pool.Future[int64], b.N)
+	for i := 0; i < b.N; i++ {
+		taskID := i
+		f, err := pool.SubmitTypedToWorker(p, i%workers, func(w *pool.Worker) int64 {
+			start := time.Now()
+			result := worker(taskID)
+			recorders[w.ID()].RecordValue(time.Since(start))
+			return result
+		})
+		if err != nil {
+			panic(err)
+		}
+		futures[i] = f
+	}
+
+	var total int64
+	for _, f := range futures {
+		result, err := f.Get()
+		if err != nil {
+			panic(err)
+		}
+		total += result
+	}
+	_ = total
+
+	lastLatencyMu.Lock()
+	lastLatency = latency.Merge(recorders...)
+	lastLatencyMu.Unlock()
+}
+
+// LastLatency returns the merged per-task latency distribution recorded
+// by the most recently completed Benchmark_FanOut run.
+func LastLatency() *latency.Recorder {
+	lastLatencyMu.Lock()
+	defer lastLatencyMu.Unlock()
+	return lastLatency
+}
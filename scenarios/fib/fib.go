@@ -0,0 +1,63 @@
+// Package fib benchmarks recursive fibonacci, in both a naive
+// double-recursive form and a tail-recursive/iterative form, to compare
+// call-stack-heavy vs loop-heavy CPU workloads.
+package fib
+
+import (
+	"metal0/bench"
+	"metal0/pool"
+)
+
+func fibonacci(n int) int {
+	if n <= 1 {
+		return n
+	}
+	return fibonacci(n-1) + fibonacci(n-2)
+}
+
+func fibTail(n int, a, b uint64) uint64 {
+	if n == 0 {
+		return a
+	}
+	return fibTail(n-1, b, a+b)
+}
+
+// Benchmark_Fibonacci computes the naive double-recursive fibonacci(30)
+// b.N times.
+func Benchmark_Fibonacci(b *bench.B) {
+	for i := 0; i < b.N; i++ {
+		fibonacci(30)
+	}
+}
+
+// Benchmark_FibonacciTail computes the tail-recursive fibonacci up to
+// n=10000 b.N times.
+func Benchmark_FibonacciTail(b *bench.B) {
+	for i := 0; i < b.N; i++ {
+		fibTail(10000, 0, 1)
+	}
+}
+
+// Benchmark_FibonacciParallel submits b.N naive fibonacci(30)
+// computations to a Pool and waits for them all, the pooled replacement
+// for spawning b.N raw goroutines.
+func Benchmark_FibonacciParallel(b *bench.B) {
+	p := pool.New()
+	defer p.Close()
+
+	futures := make([]*pool.Future[int], b.N)
+	for i := 0; i < b.N; i++ {
+		f, err := pool.SubmitTyped(p, func() int {
+			return fibonacci(30)
+		})
+		if err != nil {
+			panic(err)
+		}
+		futures[i] = f
+	}
+	for _, f := range futures {
+		if _, err := f.Get(); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Package httpbench benchmarks a small JSON HTTP handler end to end over
+// an in-process httptest.Server, and reports the resulting per-request
+// latency distribution.
+package httpbench
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"time"
+
+	"metal0/bench"
+	"metal0/bench/latency"
+)
+
+type message struct {
+	Message string `json:"message"`
+}
+
+func jsonHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message{Message: "Hello, World!"})
+}
+
+var (
+	lastLatencyMu sync.Mutex
+	lastLatency   *latency.Recorder
+)
+
+// Benchmark_HTTP runs b.N GET requests against an in-process JSON
+// handler across runtime.NumCPU() long-lived client workers, each
+// recording its own latency.Recorder. The merged distribution is
+// available afterwards via LastLatency.
+func Benchmark_HTTP(b *bench.B) {
+	server := httptest.NewServer(http.HandlerFunc(jsonHandler))
+	defer server.Close()
+	client := server.Client()
+
+	workers := runtime.NumCPU()
+	jobs := make(chan struct{}, b.N)
+	for i := 0; i < b.N; i++ {
+		jobs <- struct{}{}
+	}
+	close(jobs)
+
+	recorders := make([]*latency.Recorder, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			rec := latency.NewRecorder()
+			recorders[w] = rec
+			for range jobs {
+				start := time.Now()
+				resp, err := client.Get(server.URL)
+				if err != nil {
+					continue
+				}
+				resp.Body.Close()
+				rec.RecordValue(time.Since(start))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	lastLatencyMu.Lock()
+	lastLatency = latency.Merge(recorders...)
+	lastLatencyMu.Unlock()
+}
+
+// LastLatency returns the merged per-request latency distribution
+// recorded by the most recently completed Benchmark_HTTP run.
+func LastLatency() *latency.Recorder {
+	lastLatencyMu.Lock()
+	defer lastLatencyMu.Unlock()
+	return lastLatency
+}
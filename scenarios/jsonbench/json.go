@@ -0,0 +1,79 @@
+// Package jsonbench compares encoding/json's Unmarshal into interface{}
+// against metal0/json's arena-based Parse, both decoding the same
+// generated document, so the benchmark report shows what the
+// allocation-per-node/reflection cost actually buys.
+package jsonbench
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"metal0/bench"
+	mjson "metal0/json"
+)
+
+// sampleRecords controls the size of the document genSample returns:
+// large enough that parsing it does real work, small enough that
+// generating it isn't itself the bottleneck being measured.
+const sampleRecords = 200
+
+// genSample deterministically builds a nested JSON document (an array of
+// records, each with scalar fields plus a nested array and object) of
+// sampleRecords elements. Generating it in-process keeps the benchmark
+// self-contained instead of depending on a fixture file that isn't
+// committed anywhere.
+func genSample() []byte {
+	var sb strings.Builder
+	sb.WriteString(`{"records":[`)
+	for i := 0; i < sampleRecords; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, `{"id":%d,"name":"user%d","active":%t,"score":%.2f,"tags":["a%d","b%d","c%d"],"address":{"city":"city%d","zip":"%05d"},"meta":null}`,
+			i, i, i%2 == 0, float64(i)*1.5, i%5, i%7, i%11, i%20, i)
+	}
+	sb.WriteString(`]}`)
+	return []byte(sb.String())
+}
+
+// Benchmark_JSONParse unmarshals the generated sample document into
+// interface{} b.N times.
+func Benchmark_JSONParse(b *bench.B) {
+	data := genSample()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var result interface{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Benchmark_JSONParseMetal0 parses the generated sample document into a
+// metal0/json Value tree b.N times, reusing the same Arena across
+// iterations so only the first few calls grow it.
+func Benchmark_JSONParseMetal0(b *bench.B) {
+	data := genSample()
+	arena := mjson.NewArena(256, 64)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		arena.Reset()
+		if _, err := mjson.Parse(data, arena); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Scenarios returns the stdlib and metal0/json variants as a pair of
+// bench.Scenarios, so cmd/bench's report puts them side by side.
+func Scenarios() []bench.Scenario {
+	return []bench.Scenario{
+		{Name: "JSONParse_Stdlib", Fn: Benchmark_JSONParse},
+		{Name: "JSONParse_Metal0", Fn: Benchmark_JSONParseMetal0},
+	}
+}
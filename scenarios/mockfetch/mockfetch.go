@@ -0,0 +1,79 @@
+// Package mockfetch benchmarks concurrent "network" I/O via mock fetches
+// that sleep to simulate latency, and reports the resulting per-fetch
+// latency distribution.
+package mockfetch
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"metal0/bench"
+	"metal0/bench/latency"
+	"metal0/pool"
+)
+
+const fetchDelay = 10 * time.Millisecond
+
+// ioWorkers is the pool size for this benchmark. The workload is
+// I/O-bound (each fetch blocks in time.Sleep instead of using a core),
+// so sizing the pool well above NumCPU lets many fetches overlap instead
+// of bottlenecking at the core count the way pool.New()'s default would.
+const ioWorkers = 256
+
+func fetchMock(id int) string {
+	time.Sleep(fetchDelay)
+	return fmt.Sprintf("Response %d", id)
+}
+
+var (
+	lastLatencyMu sync.Mutex
+	lastLatency   *latency.Recorder
+)
+
+// Benchmark_MockFetch runs b.N mock fetches across a Pool, each
+// recording its latency into the executing worker's own
+// latency.Recorder. The merged distribution is available afterwards via
+// LastLatency.
+func Benchmark_MockFetch(b *bench.B) {
+	p := pool.New(pool.WithWorkers(ioWorkers))
+	defer p.Close()
+	workers := p.NumWorkers()
+
+	recorders := make([]*latency.Recorder, workers)
+	for i := range recorders {
+		recorders[i] = latency.NewRecorder()
+	}
+
+	futures := make([]*pool.Future[string], b.N)
+	for i := 0; i < b.N; i++ {
+		id := i
+		f, err := pool.SubmitTypedToWorker(p, i%workers, func(w *pool.Worker) string {
+			start := time.Now()
+			result := fetchMock(id)
+			recorders[w.ID()].RecordValue(time.Since(start))
+			return result
+		})
+		if err != nil {
+			panic(err)
+		}
+		futures[i] = f
+	}
+	for _, f := range futures {
+		if _, err := f.Get(); err != nil {
+			panic(err)
+		}
+	}
+
+	lastLatencyMu.Lock()
+	lastLatency = latency.Merge(recorders...)
+	lastLatencyMu.Unlock()
+}
+
+// LastLatency returns the merged per-fetch latency distribution recorded
+// by the most recently completed Benchmark_MockFetch run.
+func LastLatency() *latency.Recorder {
+	lastLatencyMu.Lock()
+	defer lastLatencyMu.Unlock()
+	return lastLatency
+}
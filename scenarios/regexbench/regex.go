@@ -0,0 +1,165 @@
+// Package regexbench benchmarks stdlib regexp against metal0/regex, both
+// on a representative set of real-world patterns (emails, URLs, dates,
+// ...) and on adversarial patterns chosen to stress a backtracking
+// engine's worst case: redundant alternation, nested quantifiers, and a
+// large alternation of literals.
+package regexbench
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"metal0/bench"
+	"metal0/regex"
+)
+
+type pattern struct {
+	name string
+	expr string
+}
+
+var patterns = []pattern{
+	{"Email", `[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`},
+	{"URL", `https?://[^\s]+`},
+	{"Phone", `\(\d{3}\)\s?\d{3}-\d{4}|\d{3}-\d{3}-\d{4}`},
+	{"Digits", `\d+`},
+	{"WordBoundary", `\b[a-z]{4,}\b`},
+	{"DateISO", `\d{4}-\d{2}-\d{2}`},
+	{"IPv4", `\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`},
+	{"HexColor", `#[0-9a-fA-F]{6}`},
+	{"Version", `v?\d+\.\d+\.\d+`},
+	{"Alphanumeric", `[a-z]+\d+`},
+}
+
+// adversarialPatterns exercises shapes that are classic ReDoS material
+// for backtracking engines (redundant alternation inside a star, nested
+// quantifiers) plus a large literal alternation, which is the shape
+// metal0/regex routes to an Aho-Corasick trie instead of the general
+// NFA/DFA path. Go's stdlib regexp is itself RE2-derived and doesn't
+// backtrack, so it won't blow up on these either; what the comparison
+// shows is each engine's constant-factor overhead on the same
+// guaranteed-linear-time shape, not an exponential-vs-linear gap.
+var adversarialPatterns = []pattern{
+	{"PathologicalAltStar", `(a|a)*b`},
+	{"NestedQuantifier", `(a*)*b`},
+	{"LargeLiteralAlternation", strings.Join(literalAlternationWords(), "|")},
+}
+
+func literalAlternationWords() []string {
+	words := make([]string, 64)
+	for i := range words {
+		words[i] = fmt.Sprintf("needle%04d", i)
+	}
+	return words
+}
+
+// adversarialText returns the input text to run name's pattern against:
+// a long run of 'a's with no trailing 'b' for the two pathological
+// patterns (so the engine must examine the whole run before concluding
+// there's no match), or filler text sprinkled with a few of the literal
+// alternation's words.
+func adversarialText(name string) string {
+	switch name {
+	case "LargeLiteralAlternation":
+		words := literalAlternationWords()
+		var sb strings.Builder
+		for i := 0; i < 2000; i++ {
+			sb.WriteString("filler ")
+			if i%37 == 0 {
+				sb.WriteString(words[i%len(words)])
+				sb.WriteByte(' ')
+			}
+		}
+		return sb.String()
+	default:
+		return strings.Repeat("a", 28) + "c"
+	}
+}
+
+// corpusRepeats controls the size of the generated corpus loadData
+// returns: large enough that FindAll has real work to do, small enough
+// that generating it isn't itself the bottleneck being measured.
+const corpusRepeats = 500
+
+// genCorpus deterministically builds a block of synthetic text
+// containing realistic matches for every pattern in patterns (an email,
+// a URL, phone numbers, a date, an IPv4 address, a hex color, a version,
+// and an alphanumeric token) interspersed with filler, repeated
+// corpusRepeats times. Generating it in-process keeps the benchmark
+// self-contained instead of depending on a fixture file that isn't
+// committed anywhere.
+func genCorpus() string {
+	var sb strings.Builder
+	for i := 0; i < corpusRepeats; i++ {
+		fmt.Fprintf(&sb, "Contact user%d at user%[1]d@example%d.com or visit https://example%[2]d.org/path/%[1]d for details. ", i, i%10)
+		fmt.Fprintf(&sb, "Call (555) %03d-%04d or 555-%03d-%04d. ", i%1000, i%10000, (i+1)%1000, (i+1)%10000)
+		fmt.Fprintf(&sb, "Logged on %04d-%02d-%02d from 192.168.%d.%d, color #%06x, version v%d.%d.%d. ",
+			2020+i%5, 1+i%12, 1+i%28, i%256, (i*7)%256, (i*12345)&0xffffff, i%3, i%10, i%20)
+		sb.WriteString("some alphanumeric tokens like abc123 def456 filler words here to pad the corpus. ")
+	}
+	return sb.String()
+}
+
+func loadData() (string, error) {
+	return genCorpus(), nil
+}
+
+// Scenarios returns two bench.Scenarios per pattern — one running it
+// through regexp.Regexp, one through metal0/regex — for both the
+// real-world patterns (matched against genCorpus's text) and the
+// adversarial patterns (matched against adversarialText).
+func Scenarios() []bench.Scenario {
+	var scenarios []bench.Scenario
+	for _, p := range patterns {
+		p := p
+		scenarios = append(scenarios,
+			bench.Scenario{Name: "Regex_" + p.name + "_Stdlib", Fn: stdlibScenario(p.expr, loadData)},
+			bench.Scenario{Name: "Regex_" + p.name + "_Metal0", Fn: metal0Scenario(p.expr, loadData)},
+		)
+	}
+	for _, p := range adversarialPatterns {
+		p := p
+		loadText := func() (string, error) { return adversarialText(p.name), nil }
+		scenarios = append(scenarios,
+			bench.Scenario{Name: "Regex_" + p.name + "_Stdlib", Fn: stdlibScenario(p.expr, loadText)},
+			bench.Scenario{Name: "Regex_" + p.name + "_Metal0", Fn: metal0Scenario(p.expr, loadText)},
+		)
+	}
+	return scenarios
+}
+
+func stdlibScenario(expr string, loadText func() (string, error)) func(b *bench.B) {
+	return func(b *bench.B) {
+		text, err := loadText()
+		if err != nil {
+			panic(err)
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			panic(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			re.FindAllString(text, -1)
+		}
+	}
+}
+
+func metal0Scenario(expr string, loadText func() (string, error)) func(b *bench.B) {
+	return func(b *bench.B) {
+		text, err := loadText()
+		if err != nil {
+			panic(err)
+		}
+		re, err := regex.Compile(expr)
+		if err != nil {
+			panic(err)
+		}
+		data := []byte(text)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			re.FindAllIndex(data)
+		}
+	}
+}
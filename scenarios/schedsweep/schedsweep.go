@@ -0,0 +1,90 @@
+// Package schedsweep drives metal0/bench/sched's GOMAXPROCS sweep over a
+// short-lived-goroutine fan-out burst, the same shape of load
+// scenarios/fanout and scenarios/contextswitch exercise, so the
+// scheduling-latency distribution it reports can be compared against
+// theirs at a single fixed GOMAXPROCS.
+package schedsweep
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+
+	"metal0/bench"
+	"metal0/bench/sched"
+)
+
+// goroutinesPerStep is how many short-lived goroutines fanBurst spawns
+// for each GOMAXPROCS setting in the sweep.
+const goroutinesPerStep = 64
+
+// procsToSweep returns the GOMAXPROCS values to sweep: 1, 2, 4, and the
+// machine's full core count, deduplicated and sorted (so a machine with
+// fewer than 4 cores doesn't sweep past its own NumCPU).
+func procsToSweep() []int {
+	full := runtime.NumCPU()
+	seen := make(map[int]bool)
+	var out []int
+	for _, n := range []int{1, 2, 4, full} {
+		if n < 1 || n > full || seen[n] {
+			continue
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// fanBurst spawns goroutinesPerStep goroutines, each doing a small
+// amount of CPU work itersPerGoroutine times, and waits for all of them
+// to finish — the load whose scheduling behavior Benchmark_SchedSweep
+// observes across GOMAXPROCS settings.
+func fanBurst(itersPerGoroutine int) {
+	var wg sync.WaitGroup
+	wg.Add(goroutinesPerStep)
+	for g := 0; g < goroutinesPerStep; g++ {
+		go func() {
+			defer wg.Done()
+			var x int
+			for i := 0; i < itersPerGoroutine; i++ {
+				x += i
+			}
+			_ = x
+		}()
+	}
+	wg.Wait()
+}
+
+var (
+	lastSweepMu sync.Mutex
+	lastSweep   []sched.Sample
+)
+
+// Benchmark_SchedSweep runs fanBurst once per GOMAXPROCS setting in
+// procsToSweep, each goroutine running b.N/len(procs) iterations, via
+// sched.Sweep. The
+// resulting per-setting samples are available afterwards via LastSweep.
+func Benchmark_SchedSweep(b *bench.B) {
+	procs := procsToSweep()
+	itersPerGoroutine := b.N / len(procs)
+	if itersPerGoroutine < 1 {
+		itersPerGoroutine = 1
+	}
+
+	samples := sched.Sweep(procs, func() {
+		fanBurst(itersPerGoroutine)
+	})
+
+	lastSweepMu.Lock()
+	lastSweep = samples
+	lastSweepMu.Unlock()
+}
+
+// LastSweep returns the GOMAXPROCS sweep recorded by the most recently
+// completed Benchmark_SchedSweep run.
+func LastSweep() []sched.Sample {
+	lastSweepMu.Lock()
+	defer lastSweepMu.Unlock()
+	return lastSweep
+}
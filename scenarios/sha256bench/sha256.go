@@ -0,0 +1,57 @@
+// Package sha256bench benchmarks CPU-bound work (repeated SHA-256
+// hashing) run sequentially on one goroutine versus spread across a
+// metal0/pool.Pool, so the two can be compared run-over-run to see how
+// parallel speedup changes across commits.
+package sha256bench
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+
+	"metal0/bench"
+	"metal0/pool"
+)
+
+func hashIterations(workerID, iterations int) int {
+	h := sha256.New()
+	for i := 0; i < iterations; i++ {
+		h.Write([]byte(strconv.Itoa(workerID + i)))
+	}
+	return len(fmt.Sprintf("%x", h.Sum(nil)))
+}
+
+// Benchmark_SHA256Sequential hashes b.N iterations on a single goroutine.
+func Benchmark_SHA256Sequential(b *bench.B) {
+	hashIterations(0, b.N)
+}
+
+// Benchmark_SHA256Parallel splits b.N hash iterations evenly across a
+// Pool's workers.
+func Benchmark_SHA256Parallel(b *bench.B) {
+	p := pool.New()
+	defer p.Close()
+
+	workers := p.NumWorkers()
+	perWorker := b.N / workers
+	if perWorker < 1 {
+		perWorker = 1
+	}
+
+	futures := make([]*pool.Future[int], workers)
+	for i := 0; i < workers; i++ {
+		workerID := i
+		f, err := pool.SubmitTyped(p, func() int {
+			return hashIterations(workerID, perWorker)
+		})
+		if err != nil {
+			panic(err)
+		}
+		futures[i] = f
+	}
+	for _, f := range futures {
+		if _, err := f.Get(); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Package sleep benchmarks concurrent I/O-bound work, modeled as pooled
+// workers that each sleep briefly, the way a network call would block,
+// and reports the resulting per-task latency distribution.
+package sleep
+
+import (
+	"sync"
+	"time"
+
+	"metal0/bench"
+	"metal0/bench/latency"
+	"metal0/pool"
+)
+
+const sleepPerTask = time.Millisecond
+
+// ioWorkers is the pool size for this benchmark. The workload is
+// I/O-bound (each task blocks in time.Sleep instead of using a core), so
+// sizing the pool at pool.New()'s default NumCPU() would bottleneck
+// throughput at the core count the way it would for CPU-bound work;
+// sizing it well above NumCPU lets many sleeps overlap instead.
+const ioWorkers = 256
+
+var (
+	lastLatencyMu sync.Mutex
+	lastLatency   *latency.Recorder
+)
+
+// Benchmark_Sleep runs b.N sleeps across a Pool, each task sleeping for
+// sleepPerTask and recording its latency into the executing worker's own
+// latency.Recorder (work stealing stays off, so a given worker's
+// Recorder is only ever touched by that worker's own goroutine). The
+// merged distribution is available afterwards via LastLatency.
+func Benchmark_Sleep(b *bench.B) {
+	b.ReportAllocs()
+
+	p := pool.New(pool.WithWorkers(ioWorkers))
+	defer p.Close()
+	workers := p.NumWorkers()
+
+	recorders := make([]*latency.Recorder, workers)
+	for i := range recorders {
+		recorders[i] = latency.NewRecorder()
+	}
+
+	futures := make([]*pool.Future[struct{}], b.N)
+	for i := 0; i < b.N; i++ {
+		f, err := pool.SubmitTypedToWorker(p, i%workers, func(w *pool.Worker) struct{} {
+			start := time.Now()
+			time.Sleep(sleepPerTask)
+			recorders[w.ID()].RecordValue(time.Since(start))
+			return struct{}{}
+		})
+		if err != nil {
+			panic(err)
+		}
+		futures[i] = f
+	}
+	for _, f := range futures {
+		if _, err := f.Get(); err != nil {
+			panic(err)
+		}
+	}
+
+	lastLatencyMu.Lock()
+	lastLatency = latency.Merge(recorders...)
+	lastLatencyMu.Unlock()
+}
+
+// LastLatency returns the merged per-task latency distribution recorded
+// by the most recently completed Benchmark_Sleep run.
+func LastLatency() *latency.Recorder {
+	lastLatencyMu.Lock()
+	defer lastLatencyMu.Unlock()
+	return lastLatency
+}
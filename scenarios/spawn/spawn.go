@@ -0,0 +1,22 @@
+// Package spawn benchmarks goroutine creation/scheduling overhead: how
+// fast the runtime can spawn and join N empty goroutines.
+package spawn
+
+import (
+	"sync"
+
+	"metal0/bench"
+)
+
+// Benchmark_Spawn spawns b.N empty goroutines and waits for them all to
+// finish, measuring pure task creation/scheduling overhead.
+func Benchmark_Spawn(b *bench.B) {
+	var wg sync.WaitGroup
+	wg.Add(b.N)
+	for i := 0; i < b.N; i++ {
+		go func() {
+			defer wg.Done()
+		}()
+	}
+	wg.Wait()
+}